@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+//go:generate mockgen -source=breakglasscredential_client.go -package=test -destination=test/mock_breakglasscredential_client.go
+type BreakGlassCredentialClient interface {
+	CollectionClusterSubResource[v1.BreakGlassCredential, string]
+	// RevokeAll revokes all the break glass credentials of the cluster
+	RevokeAll(ctx context.Context, clusterId string) error
+}
+
+// breakGlassCredentialClient adds RevokeAll to the collection client: OCM can't update the break glass credentials nor
+// delete them one by one, so Update and Delete return errors.ErrUnsupported without sending any request
+type breakGlassCredentialClient struct {
+	*CollectionClusterSubResourceImpl[v1.BreakGlassCredential, string]
+	collection *v1.ClustersClient
+}
+
+func NewBreakGlassCredentialClient(collection *v1.ClustersClient) BreakGlassCredentialClient {
+	return &breakGlassCredentialClient{
+		CollectionClusterSubResourceImpl: &CollectionClusterSubResourceImpl[v1.BreakGlassCredential, string]{
+			getFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmInstanceResponse[v1.BreakGlassCredential], error) {
+				return collection.Cluster(clusterId).BreakGlassCredentials().BreakGlassCredential(instanceId).Get().SendContext(ctx)
+			},
+			updateFunc: func(ctx context.Context, clusterId string, instance *v1.BreakGlassCredential) (OcmInstanceResponse[v1.BreakGlassCredential], error) {
+				return nil, fmt.Errorf("break glass credentials can't be updated: %w", errors.ErrUnsupported)
+			},
+			createFunc: func(ctx context.Context, clusterId string, instance *v1.BreakGlassCredential) (OcmInstanceResponse[v1.BreakGlassCredential], error) {
+				return collection.Cluster(clusterId).BreakGlassCredentials().Add().Body(instance).SendContext(ctx)
+			},
+			deleteFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmResponse, error) {
+				return nil, fmt.Errorf("break glass credentials can't be deleted one by one, use RevokeAll: %w", errors.ErrUnsupported)
+			},
+			listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.BreakGlassCredential], error) {
+				resp, err := collection.Cluster(clusterId).BreakGlassCredentials().List().Size(paging.size).Page(paging.page).SendContext(ctx)
+				if err = WrapError(resp, err); err != nil {
+					return nil, err
+				}
+				return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
+			},
+		},
+		collection: collection,
+	}
+}
+
+func (c *breakGlassCredentialClient) RevokeAll(ctx context.Context, clusterId string) error {
+	response, err := c.collection.Cluster(clusterId).BreakGlassCredentials().Delete().SendContext(ctx)
+	return WrapError(response, err)
+}
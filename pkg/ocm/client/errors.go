@@ -0,0 +1,163 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	sdkerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// The kinds of the errors returned by the OCM API. Use errors.Is to check the kind of the errors returned by the clients,
+// or errors.As with an *OcmError to get the details.
+var (
+	// ErrBadRequest - the request is not valid (400 and 422)
+	ErrBadRequest = errors.New("bad request")
+	// ErrForbidden - the caller is not authenticated or not allowed to perform the request (401 and 403)
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound - the resource doesn't exist (404)
+	ErrNotFound = errors.New("not found")
+	// ErrConflict - the request conflicts with the current state of the resource, ie: it already exists (409)
+	ErrConflict = errors.New("conflict")
+	// ErrThrottled - too many requests have been sent (429)
+	ErrThrottled = errors.New("throttled")
+	// ErrServerError - the OCM API failed (5xx)
+	ErrServerError = errors.New("server error")
+)
+
+// OcmError is the error returned by the clients when the OCM API answers with an error status
+type OcmError struct {
+	// Status - the HTTP status of the response
+	Status int
+	// Code - the OCM error code (ie: CLUSTERS-MGMT-404)
+	Code string
+	// Reason - the human-readable description of the error
+	Reason string
+	// Details - the additional details returned by OCM, if any
+	Details interface{}
+	// OperationID - the identifier of the failed operation, useful to look for it in the OCM logs
+	OperationID string
+	// RetryAfter - the delay requested by the Retry-After header of the response, 0 if not present
+	RetryAfter time.Duration
+	// Err - the error returned by the OCM SDK
+	Err error
+}
+
+func (e *OcmError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("status is %d: %s", e.Status, e.Reason)
+}
+
+func (e *OcmError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error is of the kind of the target (ie: ErrNotFound)
+func (e *OcmError) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// Kind returns the kind of the error (ie: ErrNotFound), nil if the status doesn't match any kind
+func (e *OcmError) Kind() error {
+	switch {
+	case e.Status == http.StatusBadRequest, e.Status == http.StatusUnprocessableEntity:
+		return ErrBadRequest
+	case e.Status == http.StatusUnauthorized, e.Status == http.StatusForbidden:
+		return ErrForbidden
+	case e.Status == http.StatusNotFound:
+		return ErrNotFound
+	case e.Status == http.StatusConflict:
+		return ErrConflict
+	case e.Status == http.StatusTooManyRequests:
+		return ErrThrottled
+	case e.Status >= http.StatusInternalServerError:
+		return ErrServerError
+	}
+	return nil
+}
+
+// WrapError converts the errors returned by the OCM SDK into an *OcmError, using the status of the response and the
+// OCM error body. Errors not related to an error status (ie: network errors) and *OcmError are returned as they are.
+// The OCM SDK returns no error for the error statuses without a body: an *OcmError is returned for them too, so
+// WrapError must be called even when err is nil.
+func WrapError(response OcmResponse, err error) error {
+	var ocmError *OcmError
+	if errors.As(err, &ocmError) {
+		return err
+	}
+
+	ocmError = &OcmError{Err: err}
+	var sdkError *sdkerrors.Error
+	if errors.As(err, &sdkError) {
+		ocmError.Status = sdkError.Status()
+		ocmError.Code = sdkError.Code()
+		ocmError.Reason = sdkError.Reason()
+		ocmError.Details = sdkError.Details()
+		ocmError.OperationID = sdkError.OperationID()
+	}
+	if ocmError.Status == 0 && response != nil {
+		ocmError.Status = response.Status()
+	}
+	if ocmError.Status < http.StatusBadRequest {
+		return err
+	}
+	if err == nil {
+		ocmError.Reason = http.StatusText(ocmError.Status)
+	}
+
+	if withHeader, ok := response.(interface{ Header() http.Header }); ok {
+		ocmError.RetryAfter = parseRetryAfter(withHeader.Header().Get("Retry-After"))
+	}
+	return ocmError
+}
+
+// parseRetryAfter parses the value of the Retry-After header, that can be a number of seconds or a date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// IsBadRequest returns true if the error is an OCM error of kind ErrBadRequest
+func IsBadRequest(err error) bool {
+	return errors.Is(err, ErrBadRequest)
+}
+
+// IsForbidden returns true if the error is an OCM error of kind ErrForbidden
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsNotFound returns true if the error is an OCM error of kind ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if the error is an OCM error of kind ErrConflict
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsThrottled returns true if the error is an OCM error of kind ErrThrottled
+func IsThrottled(err error) bool {
+	return errors.Is(err, ErrThrottled)
+}
+
+// IsServerError returns true if the error is an OCM error of kind ErrServerError
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServerError)
+}
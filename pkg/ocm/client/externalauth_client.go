@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+//go:generate mockgen -source=externalauth_client.go -package=test -destination=test/mock_externalauth_client.go
+type ExternalAuthClient interface {
+	CollectionClusterSubResource[v1.ExternalAuth, string]
+}
+
+func NewExternalAuthClient(collection *v1.ClustersClient) ExternalAuthClient {
+	return &CollectionClusterSubResourceImpl[v1.ExternalAuth, string]{
+		getFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmInstanceResponse[v1.ExternalAuth], error) {
+			return collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().ExternalAuth(instanceId).Get().SendContext(ctx)
+		},
+		updateFunc: func(ctx context.Context, clusterId string, instance *v1.ExternalAuth) (OcmInstanceResponse[v1.ExternalAuth], error) {
+			return collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().ExternalAuth(instance.ID()).Update().Body(instance).SendContext(ctx)
+		},
+		createFunc: func(ctx context.Context, clusterId string, instance *v1.ExternalAuth) (OcmInstanceResponse[v1.ExternalAuth], error) {
+			return collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().Add().Body(instance).SendContext(ctx)
+		},
+		deleteFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmResponse, error) {
+			return collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().ExternalAuth(instanceId).Delete().SendContext(ctx)
+		},
+		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.ExternalAuth], error) {
+			resp, err := collection.Cluster(clusterId).ExternalAuthConfig().ExternalAuths().List().Size(paging.size).Page(paging.page).SendContext(ctx)
+			if err = WrapError(resp, err); err != nil {
+				return nil, err
+			}
+			return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+//go:generate mockgen -source=identityprovider_client.go -package=test -destination=test/mock_identityprovider_client.go
+type IdentityProviderClient interface {
+	CollectionClusterSubResource[v1.IdentityProvider, string]
+}
+
+func NewIdentityProviderClient(collection *v1.ClustersClient) IdentityProviderClient {
+	return &CollectionClusterSubResourceImpl[v1.IdentityProvider, string]{
+		getFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmInstanceResponse[v1.IdentityProvider], error) {
+			return collection.Cluster(clusterId).IdentityProviders().IdentityProvider(instanceId).Get().SendContext(ctx)
+		},
+		updateFunc: func(ctx context.Context, clusterId string, instance *v1.IdentityProvider) (OcmInstanceResponse[v1.IdentityProvider], error) {
+			return collection.Cluster(clusterId).IdentityProviders().IdentityProvider(instance.ID()).Update().Body(instance).SendContext(ctx)
+		},
+		createFunc: func(ctx context.Context, clusterId string, instance *v1.IdentityProvider) (OcmInstanceResponse[v1.IdentityProvider], error) {
+			return collection.Cluster(clusterId).IdentityProviders().Add().Body(instance).SendContext(ctx)
+		},
+		deleteFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmResponse, error) {
+			return collection.Cluster(clusterId).IdentityProviders().IdentityProvider(instanceId).Delete().SendContext(ctx)
+		},
+		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.IdentityProvider], error) {
+			resp, err := collection.Cluster(clusterId).IdentityProviders().List().Size(paging.size).Page(paging.page).SendContext(ctx)
+			if err = WrapError(resp, err); err != nil {
+				return nil, err
+			}
+			return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+//go:generate mockgen -source=ingress_client.go -package=test -destination=test/mock_ingress_client.go
+type IngressClient interface {
+	CollectionClusterSubResource[v1.Ingress, string]
+}
+
+func NewIngressClient(collection *v1.ClustersClient) IngressClient {
+	return &CollectionClusterSubResourceImpl[v1.Ingress, string]{
+		getFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmInstanceResponse[v1.Ingress], error) {
+			return collection.Cluster(clusterId).Ingresses().Ingress(instanceId).Get().SendContext(ctx)
+		},
+		updateFunc: func(ctx context.Context, clusterId string, instance *v1.Ingress) (OcmInstanceResponse[v1.Ingress], error) {
+			return collection.Cluster(clusterId).Ingresses().Ingress(instance.ID()).Update().Body(instance).SendContext(ctx)
+		},
+		createFunc: func(ctx context.Context, clusterId string, instance *v1.Ingress) (OcmInstanceResponse[v1.Ingress], error) {
+			return collection.Cluster(clusterId).Ingresses().Add().Body(instance).SendContext(ctx)
+		},
+		deleteFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmResponse, error) {
+			return collection.Cluster(clusterId).Ingresses().Ingress(instanceId).Delete().SendContext(ctx)
+		},
+		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.Ingress], error) {
+			resp, err := collection.Cluster(clusterId).Ingresses().List().Size(paging.size).Page(paging.page).SendContext(ctx)
+			if err = WrapError(resp, err); err != nil {
+				return nil, err
+			}
+			return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
+		},
+	}
+}
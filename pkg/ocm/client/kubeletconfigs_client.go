@@ -27,10 +27,10 @@ func NewKubeletConfigsClient(collection *v1.ClustersClient) KubeletConfigsClient
 		},
 		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.KubeletConfig], error) {
 			resp, err := collection.Cluster(clusterId).KubeletConfigs().List().Size(paging.size).Page(paging.page).SendContext(ctx)
-			if err != nil {
+			if err = WrapError(resp, err); err != nil {
 				return nil, err
 			}
-			return NewListResponse(resp.Status(), resp.Items().Slice()), nil
+			return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
 		},
 	}
 }
@@ -26,10 +26,10 @@ func NewNodePoolClient(collection *v1.ClustersClient) NodePoolClient {
 		},
 		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.NodePool], error) {
 			response, err := collection.Cluster(clusterId).NodePools().List().Size(paging.size).Page(paging.page).SendContext(ctx)
-			if err != nil {
+			if err = WrapError(response, err); err != nil {
 				return nil, err
 			}
-			return NewListResponse(response.Status(), response.Items().Slice()), nil
+			return NewPagedListResponse(response.Status(), response.Items().Slice(), response.Page(), response.Size(), response.Total()), nil
 		},
 	}
 }
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+//go:generate mockgen -source=nodepoolupgradepolicy_client.go -package=test -destination=test/mock_nodepoolupgradepolicy_client.go
+type NodePoolUpgradePolicyClient interface {
+	CollectionClusterSubResource[v1.NodePoolUpgradePolicy, string]
+}
+
+// NewNodePoolUpgradePolicyClient returns a client for the upgrade policies of the node pool nodePoolId. The upgrade
+// policies belong to a node pool, so a client is needed for each node pool
+func NewNodePoolUpgradePolicyClient(collection *v1.ClustersClient, nodePoolId string) NodePoolUpgradePolicyClient {
+	return &CollectionClusterSubResourceImpl[v1.NodePoolUpgradePolicy, string]{
+		getFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmInstanceResponse[v1.NodePoolUpgradePolicy], error) {
+			return collection.Cluster(clusterId).NodePools().NodePool(nodePoolId).UpgradePolicies().NodePoolUpgradePolicy(instanceId).Get().SendContext(ctx)
+		},
+		updateFunc: func(ctx context.Context, clusterId string, instance *v1.NodePoolUpgradePolicy) (OcmInstanceResponse[v1.NodePoolUpgradePolicy], error) {
+			return collection.Cluster(clusterId).NodePools().NodePool(nodePoolId).UpgradePolicies().NodePoolUpgradePolicy(instance.ID()).Update().Body(instance).SendContext(ctx)
+		},
+		createFunc: func(ctx context.Context, clusterId string, instance *v1.NodePoolUpgradePolicy) (OcmInstanceResponse[v1.NodePoolUpgradePolicy], error) {
+			return collection.Cluster(clusterId).NodePools().NodePool(nodePoolId).UpgradePolicies().Add().Body(instance).SendContext(ctx)
+		},
+		deleteFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmResponse, error) {
+			return collection.Cluster(clusterId).NodePools().NodePool(nodePoolId).UpgradePolicies().NodePoolUpgradePolicy(instanceId).Delete().SendContext(ctx)
+		},
+		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.NodePoolUpgradePolicy], error) {
+			resp, err := collection.Cluster(clusterId).NodePools().NodePool(nodePoolId).UpgradePolicies().List().Size(paging.size).Page(paging.page).SendContext(ctx)
+			if err = WrapError(resp, err); err != nil {
+				return nil, err
+			}
+			return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
+		},
+	}
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// RetryPolicy configures the retries of the clients returned by WithRetry and WithSingleRetry
+type RetryPolicy struct {
+	// MaxAttempts - the maximum number of requests sent for each call, including the first one. Values lower than 2
+	// disable the retries
+	MaxAttempts int
+	// InitialDelay - the delay before the first retry
+	InitialDelay time.Duration
+	// MaxDelay - the maximum delay between two attempts, 0 for no limit. It doesn't limit the delays requested by OCM
+	// with the Retry-After header
+	MaxDelay time.Duration
+	// Multiplier - the factor applied to the delay after each retry. Values lower than 1 keep the delay constant
+	Multiplier float64
+	// Jitter - the fraction (between 0 and 1) of the delay that is randomly removed, so that the clients failing at the
+	// same time don't retry at the same time
+	Jitter float64
+	// RetryCreate - retry the Create requests on server and network errors too. Those requests may have been processed,
+	// so only enable it when creating an instance twice fails (ie: the instances are created with their ID). Create
+	// requests are always retried when throttled, as OCM didn't process them
+	RetryCreate bool
+}
+
+// DefaultRetryPolicy returns a policy sending up to 4 requests, waiting 0.5s, 1s and 2s (minus up to 20% of jitter)
+// between them
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  4,
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// retryable returns true if the request failed with a transient error: throttling, server or network errors. idempotent
+// is false for the requests that can't be safely sent twice if they have been processed.
+func (p RetryPolicy) retryable(err error, idempotent bool) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if IsThrottled(err) {
+		return true
+	}
+	var ocmError *OcmError
+	if errors.As(err, &ocmError) {
+		return idempotent && IsServerError(err)
+	}
+	var netError net.Error
+	return idempotent && (errors.As(err, &netError) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF))
+}
+
+// delay returns the delay before the retry number retry (0 based), using the one requested by OCM if longer
+func (p RetryPolicy) delay(retry int, err error) time.Duration {
+	delay := float64(p.InitialDelay) * math.Pow(math.Max(p.Multiplier, 1), float64(retry))
+	if p.MaxDelay > 0 {
+		delay = math.Min(delay, float64(p.MaxDelay))
+	}
+	delay -= delay * math.Min(math.Max(p.Jitter, 0), 1) * rand.Float64()
+
+	var ocmError *OcmError
+	if errors.As(err, &ocmError) && float64(ocmError.RetryAfter) > delay {
+		return ocmError.RetryAfter
+	}
+	return time.Duration(delay)
+}
+
+// retry calls the operation until it succeeds, fails with an error that can't be retried or the attempts are exhausted.
+// It doesn't wait beyond the deadline of the context: when the next attempt would start too late, the last error is
+// returned immediately.
+func retry[R any](ctx context.Context, policy RetryPolicy, idempotent bool, operation func() (R, error)) (R, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := operation()
+		if attempt >= policy.MaxAttempts || !policy.retryable(err, idempotent) {
+			return result, err
+		}
+
+		delay := policy.delay(attempt-1, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return result, err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// retryingCollectionClusterSubResource retries the requests of a CollectionClusterSubResource, see WithRetry
+type retryingCollectionClusterSubResource[T any, S any] struct {
+	delegate CollectionClusterSubResource[T, S]
+	policy   RetryPolicy
+}
+
+// WithRetry returns a client sending again the requests of the received one when they fail with transient errors
+// (throttling, server and network errors), waiting between the attempts as configured by the policy. Create requests
+// are only retried when they haven't been processed, unless RetryPolicy.RetryCreate is set. Delete requests are
+// retried too: a failed attempt may have deleted the instance, so a not found error on a retry is a success.
+// ListAll and All retry the requests of each page, instead of starting again from the first page. The paging metadata
+// returned by OCM is used when the received client is one of the clients of this package.
+func WithRetry[T any, S any](delegate CollectionClusterSubResource[T, S], policy RetryPolicy) CollectionClusterSubResource[T, S] {
+	return &retryingCollectionClusterSubResource[T, S]{delegate: delegate, policy: policy}
+}
+
+func (r *retryingCollectionClusterSubResource[T, S]) Get(ctx context.Context, clusterId string, instanceId S) (*T, error) {
+	return retry(ctx, r.policy, true, func() (*T, error) {
+		return r.delegate.Get(ctx, clusterId, instanceId)
+	})
+}
+
+func (r *retryingCollectionClusterSubResource[T, S]) Exists(ctx context.Context, clusterId string, instanceId S) (bool, *T, error) {
+	var exists bool
+	instance, err := retry(ctx, r.policy, true, func() (*T, error) {
+		var err error
+		var instance *T
+		exists, instance, err = r.delegate.Exists(ctx, clusterId, instanceId)
+		return instance, err
+	})
+	return exists, instance, err
+}
+
+func (r *retryingCollectionClusterSubResource[T, S]) Create(ctx context.Context, clusterId string, instance *T) (*T, error) {
+	return retry(ctx, r.policy, r.policy.RetryCreate, func() (*T, error) {
+		return r.delegate.Create(ctx, clusterId, instance)
+	})
+}
+
+func (r *retryingCollectionClusterSubResource[T, S]) Update(ctx context.Context, clusterId string, instance *T) (*T, error) {
+	return retry(ctx, r.policy, true, func() (*T, error) {
+		return r.delegate.Update(ctx, clusterId, instance)
+	})
+}
+
+func (r *retryingCollectionClusterSubResource[T, S]) Delete(ctx context.Context, clusterId string, instanceId S) error {
+	return retryDelete(ctx, r.policy, func() error {
+		return r.delegate.Delete(ctx, clusterId, instanceId)
+	})
+}
+
+func (r *retryingCollectionClusterSubResource[T, S]) List(ctx context.Context, clusterId string, paging Paging) ([]*T, bool, error) {
+	var hasItems bool
+	items, err := retry(ctx, r.policy, true, func() ([]*T, error) {
+		var err error
+		var items []*T
+		items, hasItems, err = r.delegate.List(ctx, clusterId, paging)
+		return items, err
+	})
+	return items, hasItems, err
+}
+
+// listPage retries the request of a page, so that All doesn't start again from the first page
+func (r *retryingCollectionClusterSubResource[T, S]) listPage(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[T], error) {
+	return retry(ctx, r.policy, true, func() (OcmListResponse[T], error) {
+		return listPage(ctx, r.delegate, clusterId, paging)
+	})
+}
+
+// retryingSingleClusterSubResource retries the requests of a SingleClusterSubResource, see WithSingleRetry
+type retryingSingleClusterSubResource[T any] struct {
+	delegate SingleClusterSubResource[T]
+	policy   RetryPolicy
+}
+
+// WithSingleRetry is the equivalent of WithRetry for the SingleClusterSubResource clients
+func WithSingleRetry[T any](delegate SingleClusterSubResource[T], policy RetryPolicy) SingleClusterSubResource[T] {
+	return &retryingSingleClusterSubResource[T]{delegate: delegate, policy: policy}
+}
+
+func (r *retryingSingleClusterSubResource[T]) Get(ctx context.Context, clusterId string) (*T, error) {
+	return retry(ctx, r.policy, true, func() (*T, error) {
+		return r.delegate.Get(ctx, clusterId)
+	})
+}
+
+func (r *retryingSingleClusterSubResource[T]) Exists(ctx context.Context, clusterId string) (bool, *T, error) {
+	var exists bool
+	instance, err := retry(ctx, r.policy, true, func() (*T, error) {
+		var err error
+		var instance *T
+		exists, instance, err = r.delegate.Exists(ctx, clusterId)
+		return instance, err
+	})
+	return exists, instance, err
+}
+
+func (r *retryingSingleClusterSubResource[T]) Create(ctx context.Context, clusterId string, instance *T) (*T, error) {
+	return retry(ctx, r.policy, r.policy.RetryCreate, func() (*T, error) {
+		return r.delegate.Create(ctx, clusterId, instance)
+	})
+}
+
+func (r *retryingSingleClusterSubResource[T]) Update(ctx context.Context, clusterId string, instance *T) (*T, error) {
+	return retry(ctx, r.policy, true, func() (*T, error) {
+		return r.delegate.Update(ctx, clusterId, instance)
+	})
+}
+
+func (r *retryingSingleClusterSubResource[T]) Delete(ctx context.Context, clusterId string) error {
+	return retryDelete(ctx, r.policy, func() error {
+		return r.delegate.Delete(ctx, clusterId)
+	})
+}
+
+// retryDelete retries a delete operation. An attempt failing with a transient error may have deleted the instance
+// anyway, so a not found error returned by the following attempts is a success
+func retryDelete(ctx context.Context, policy RetryPolicy, operation func() error) error {
+	attempt := 0
+	_, err := retry(ctx, policy, true, func() (struct{}, error) {
+		attempt++
+		err := operation()
+		if attempt > 1 && IsNotFound(err) {
+			return struct{}{}, nil
+		}
+		return struct{}{}, err
+	})
+	return err
+}
+
+var _ SingleClusterSubResource[interface{}] = &retryingSingleClusterSubResource[interface{}]{}
+var _ CollectionClusterSubResource[interface{}, string] = &retryingCollectionClusterSubResource[interface{}, string]{}
+var _ pageLister[interface{}] = &retryingCollectionClusterSubResource[interface{}, string]{}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift-online/ocm-common/pkg/ocm/client"
+)
+
+var _ = Describe("Clients", func() {
+	var clusters *v1.ClustersClient
+	var requests []string
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		requests = nil
+		clusters = v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			requests = append(requests, request.Method+" "+request.URL.Path)
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("Content-Type", "application/json")
+			_, _ = recorder.WriteString(`{"id":"abc","items":[]}`)
+			return recorder.Result(), nil
+		}), "/api/clusters_mgmt/v1/clusters")
+	})
+
+	DescribeTable("Sends the requests to the sub resources of the cluster",
+		func(newClient func(clusters *v1.ClustersClient) func() error, path string) {
+			Expect(newClient(clusters)()).To(Succeed())
+			Expect(requests).To(Equal([]string{
+				"GET " + path + "/abc",
+				"POST " + path,
+				"PATCH " + path + "/abc",
+				"DELETE " + path + "/abc",
+				"GET " + path,
+			}))
+		},
+		Entry("Identity providers", func(clusters *v1.ClustersClient) func() error {
+			return callAll[v1.IdentityProvider](client.NewIdentityProviderClient(clusters), NewIdentityProvider)
+		}, "/api/clusters_mgmt/v1/clusters/123/identity_providers"),
+		Entry("Ingresses", func(clusters *v1.ClustersClient) func() error {
+			return callAll[v1.Ingress](client.NewIngressClient(clusters), NewIngress)
+		}, "/api/clusters_mgmt/v1/clusters/123/ingresses"),
+		Entry("Upgrade policies", func(clusters *v1.ClustersClient) func() error {
+			return callAll[v1.UpgradePolicy](client.NewUpgradePolicyClient(clusters), NewUpgradePolicy)
+		}, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies"),
+		Entry("Node pool upgrade policies", func(clusters *v1.ClustersClient) func() error {
+			return callAll[v1.NodePoolUpgradePolicy](client.NewNodePoolUpgradePolicyClient(clusters, "workers"), NewNodePoolUpgradePolicy)
+		}, "/api/clusters_mgmt/v1/clusters/123/node_pools/workers/upgrade_policies"),
+		Entry("External auths", func(clusters *v1.ClustersClient) func() error {
+			return callAll[v1.ExternalAuth](client.NewExternalAuthClient(clusters), NewExternalAuth)
+		}, "/api/clusters_mgmt/v1/clusters/123/external_auth_config/external_auths"),
+	)
+
+	Context("Break glass credentials", func() {
+		var credentials client.BreakGlassCredentialClient
+
+		BeforeEach(func() {
+			credentials = client.NewBreakGlassCredentialClient(clusters)
+		})
+
+		It("Sends the requests to the break glass credentials of the cluster", func() {
+			credential, err := NewBreakGlassCredential()
+			Expect(err).NotTo(HaveOccurred())
+			path := "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"
+
+			_, err = credentials.Get(ctx, "123", "abc")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = credentials.Create(ctx, "123", credential)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.ListAll(ctx, credentials, "123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials.RevokeAll(ctx, "123")).To(Succeed())
+			Expect(requests).To(Equal([]string{
+				"GET " + path + "/abc",
+				"POST " + path,
+				"GET " + path,
+				"DELETE " + path,
+			}))
+		})
+
+		It("Doesn't support updating or deleting a credential", func() {
+			credential, err := NewBreakGlassCredential(func(k *v1.BreakGlassCredentialBuilder) {
+				k.ID("abc")
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = credentials.Update(ctx, "123", credential)
+			Expect(errors.Is(err, errors.ErrUnsupported)).To(BeTrue())
+			Expect(errors.Is(credentials.Delete(ctx, "123", "abc"), errors.ErrUnsupported)).To(BeTrue())
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
+
+// callAll returns a function calling Get, Create, Update, Delete and client.ListAll on the client, creating an empty instance
+// built by newInstance
+func callAll[T any, B any](c client.CollectionClusterSubResource[T, string], newInstance func(modifyFn ...func(k *B)) (*T, error)) func() error {
+	return func() error {
+		instance, err := newInstance()
+		if err != nil {
+			return err
+		}
+		// the instances are returned by OCM with their ID, so the one returned by Get is used for Update
+		existing, err := c.Get(context.Background(), "123", "abc")
+		if err != nil {
+			return err
+		}
+		if _, err := c.Create(context.Background(), "123", instance); err != nil {
+			return err
+		}
+		if _, err := c.Update(context.Background(), "123", existing); err != nil {
+			return err
+		}
+		if err := c.Delete(context.Background(), "123", "abc"); err != nil {
+			return err
+		}
+		_, err = client.ListAll(context.Background(), c, "123")
+		return err
+	}
+}
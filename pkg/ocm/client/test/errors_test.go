@@ -0,0 +1,250 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	sdkerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift-online/ocm-common/pkg/ocm/client"
+)
+
+// headerResponse - an OcmResponse exposing its headers, like the responses of the OCM SDK
+type headerResponse struct {
+	status int
+	header http.Header
+}
+
+func (r headerResponse) Status() int {
+	return r.status
+}
+
+func (r headerResponse) Header() http.Header {
+	return r.header
+}
+
+var _ = Describe("Errors", func() {
+	sdkError := func(status int) error {
+		err, buildErr := sdkerrors.NewError().Status(status).Reason("failure").Build()
+		Expect(buildErr).NotTo(HaveOccurred())
+		return err
+	}
+
+	DescribeTable("Kinds", func(status int, expectedKind error) {
+		err := client.WrapError(nil, sdkError(status))
+		Expect(errors.Is(err, expectedKind)).To(BeTrue())
+		for _, kind := range []error{
+			client.ErrBadRequest, client.ErrForbidden, client.ErrNotFound,
+			client.ErrConflict, client.ErrThrottled, client.ErrServerError,
+		} {
+			if kind != expectedKind {
+				Expect(errors.Is(err, kind)).To(BeFalse(), "%d is not %v", status, kind)
+			}
+		}
+	},
+		Entry("400", http.StatusBadRequest, client.ErrBadRequest),
+		Entry("422", http.StatusUnprocessableEntity, client.ErrBadRequest),
+		Entry("401", http.StatusUnauthorized, client.ErrForbidden),
+		Entry("403", http.StatusForbidden, client.ErrForbidden),
+		Entry("404", http.StatusNotFound, client.ErrNotFound),
+		Entry("409", http.StatusConflict, client.ErrConflict),
+		Entry("429", http.StatusTooManyRequests, client.ErrThrottled),
+		Entry("500", http.StatusInternalServerError, client.ErrServerError),
+		Entry("503", http.StatusServiceUnavailable, client.ErrServerError),
+	)
+
+	It("Provides helpers for the kinds", func() {
+		Expect(client.IsBadRequest(client.WrapError(nil, sdkError(http.StatusBadRequest)))).To(BeTrue())
+		Expect(client.IsForbidden(client.WrapError(nil, sdkError(http.StatusForbidden)))).To(BeTrue())
+		Expect(client.IsNotFound(client.WrapError(nil, sdkError(http.StatusNotFound)))).To(BeTrue())
+		Expect(client.IsConflict(client.WrapError(nil, sdkError(http.StatusConflict)))).To(BeTrue())
+		Expect(client.IsThrottled(client.WrapError(nil, sdkError(http.StatusTooManyRequests)))).To(BeTrue())
+		Expect(client.IsServerError(client.WrapError(nil, sdkError(http.StatusBadGateway)))).To(BeTrue())
+		Expect(client.IsNotFound(fmt.Errorf("wrapped: %w", client.WrapError(nil, sdkError(http.StatusNotFound))))).To(BeTrue())
+		Expect(client.IsNotFound(errors.New("not found"))).To(BeFalse())
+	})
+
+	It("Keeps the message and the error of the OCM SDK", func() {
+		original := sdkError(http.StatusNotFound)
+		err := client.WrapError(nil, original)
+		Expect(err.Error()).To(Equal(original.Error()))
+
+		var unwrapped *sdkerrors.Error
+		Expect(errors.As(err, &unwrapped)).To(BeTrue())
+		Expect(unwrapped).To(BeIdenticalTo(original))
+	})
+
+	It("Uses the status of the response when the error has none", func() {
+		err := client.WrapError(headerResponse{status: http.StatusConflict}, errors.New("failure"))
+		Expect(client.IsConflict(err)).To(BeTrue())
+	})
+
+	It("Builds the error from the response when the OCM SDK returns none", func() {
+		header := http.Header{}
+		header.Set("Retry-After", "5")
+		err := client.WrapError(headerResponse{status: http.StatusTooManyRequests, header: header}, nil)
+		Expect(client.IsThrottled(err)).To(BeTrue())
+		Expect(err).To(MatchError("status is 429: Too Many Requests"))
+		var ocmError *client.OcmError
+		Expect(errors.As(err, &ocmError)).To(BeTrue())
+		Expect(ocmError.RetryAfter).To(Equal(5 * time.Second))
+		Expect(client.WrapError(headerResponse{status: http.StatusOK}, nil)).To(BeNil())
+	})
+
+	It("Returns the errors not related to an error status as they are", func() {
+		original := errors.New("connection refused")
+		Expect(client.WrapError(nil, original)).To(BeIdenticalTo(original))
+		Expect(client.WrapError(headerResponse{status: http.StatusOK}, original)).To(BeIdenticalTo(original))
+		Expect(client.WrapError(nil, nil)).To(BeNil())
+	})
+
+	It("Doesn't wrap the errors twice", func() {
+		err := client.WrapError(nil, sdkError(http.StatusNotFound))
+		Expect(client.WrapError(headerResponse{status: http.StatusConflict}, err)).To(BeIdenticalTo(err))
+	})
+
+	// the dates are computed when the specs run, as the delays are relative to the current time
+	date := func(delay time.Duration) func() string {
+		return func() string { return time.Now().Add(delay).UTC().Format(http.TimeFormat) }
+	}
+	literal := func(value string) func() string {
+		return func() string { return value }
+	}
+
+	DescribeTable("Retry-After", func(header func() string, expectedDelay time.Duration) {
+		response := headerResponse{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{header()}}}
+		var ocmError *client.OcmError
+		Expect(errors.As(client.WrapError(response, sdkError(http.StatusTooManyRequests)), &ocmError)).To(BeTrue())
+		// the dates have a resolution of one second
+		Expect(ocmError.RetryAfter).To(BeNumerically("~", expectedDelay, time.Second))
+	},
+		Entry("Seconds", literal("120"), 2*time.Minute),
+		Entry("Date", date(time.Minute), time.Minute),
+		Entry("Past date", date(-time.Minute), time.Duration(0)),
+		Entry("Invalid", literal("soon"), time.Duration(0)),
+	)
+
+	Context("Clients", func() {
+		var clusters *v1.ClustersClient
+		var status int
+		var body string
+		var header http.Header
+
+		BeforeEach(func() {
+			header = http.Header{}
+			clusters = v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				recorder := httptest.NewRecorder()
+				for name, values := range header {
+					recorder.Header()[name] = values
+				}
+				recorder.Header().Set("Content-Type", "application/json")
+				recorder.WriteHeader(status)
+				_, _ = recorder.WriteString(body)
+				return recorder.Result(), nil
+			}), "/api/clusters_mgmt/v1/clusters")
+		})
+
+		errorBody := func(status int, reason string, details string) string {
+			return fmt.Sprintf(`{"kind":"Error","id":"%d","code":"CLUSTERS-MGMT-%d","reason":%q,"operation_id":"op-1","details":%s}`,
+				status, status, reason, details)
+		}
+
+		It("Returns the reason and the details of the OCM errors", func() {
+			status = http.StatusBadRequest
+			body = errorBody(status, "Invalid replicas", `[{"field":"replicas"}]`)
+
+			_, err := client.NewMachinePoolClient(clusters).Create(context.Background(), "123", &v1.MachinePool{})
+			var ocmError *client.OcmError
+			Expect(errors.As(err, &ocmError)).To(BeTrue())
+			Expect(ocmError.Status).To(Equal(http.StatusBadRequest))
+			Expect(ocmError.Code).To(Equal("CLUSTERS-MGMT-400"))
+			Expect(ocmError.Reason).To(Equal("Invalid replicas"))
+			Expect(ocmError.OperationID).To(Equal("op-1"))
+			Expect(ocmError.Details).To(Equal([]interface{}{map[string]interface{}{"field": "replicas"}}))
+			Expect(client.IsBadRequest(err)).To(BeTrue())
+		})
+
+		It("Returns typed errors from the list requests", func() {
+			status = http.StatusForbidden
+			header.Set("Retry-After", "30")
+			body = errorBody(status, "Forbidden", "null")
+
+			_, err := client.ListAll(context.Background(), client.NewNodePoolClient(clusters), "123")
+			Expect(client.IsForbidden(err)).To(BeTrue())
+			var ocmError *client.OcmError
+			Expect(errors.As(err, &ocmError)).To(BeTrue())
+			Expect(ocmError.RetryAfter).To(Equal(30 * time.Second))
+		})
+
+		It("Reports the missing resources as not existing", func() {
+			status = http.StatusNotFound
+			body = errorBody(status, "Not found", "null")
+
+			exists, _, err := client.NewKubeletConfigClient(clusters).Exists(context.Background(), "123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			err = client.NewMachinePoolClient(clusters).Delete(context.Background(), "123", "abc")
+			Expect(client.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Responses without a body", func() {
+		var server *httptest.Server
+		var clusters *v1.ClustersClient
+		var status int
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Header().Set("Retry-After", "7")
+				writer.WriteHeader(status)
+			}))
+			clusters = v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				// the OCM SDK sends the requests with the path only
+				request.URL.Scheme = "http"
+				request.URL.Host = server.Listener.Addr().String()
+				return http.DefaultTransport.RoundTrip(request)
+			}), "/api/clusters_mgmt/v1/clusters")
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		DescribeTable("Returns typed errors", func(statusCode int, expectedKind error) {
+			status = statusCode
+			machinePools := client.NewMachinePoolClient(clusters)
+
+			machinePool, err := machinePools.Get(context.Background(), "123", "abc")
+			Expect(machinePool).To(BeNil())
+			Expect(errors.Is(err, expectedKind)).To(BeTrue(), "unexpected error %v", err)
+			var ocmError *client.OcmError
+			Expect(errors.As(err, &ocmError)).To(BeTrue())
+			Expect(ocmError.Status).To(Equal(statusCode))
+			Expect(ocmError.RetryAfter).To(Equal(7 * time.Second))
+
+			_, err = client.ListAll(context.Background(), machinePools, "123")
+			Expect(errors.Is(err, expectedKind)).To(BeTrue(), "unexpected error %v", err)
+			Expect(errors.Is(machinePools.Delete(context.Background(), "123", "abc"), expectedKind)).To(BeTrue())
+		},
+			Entry("404", http.StatusNotFound, client.ErrNotFound),
+			Entry("429", http.StatusTooManyRequests, client.ErrThrottled),
+			Entry("503", http.StatusServiceUnavailable, client.ErrServerError),
+		)
+
+		It("Reports the missing resources as not existing", func() {
+			status = http.StatusNotFound
+
+			exists, _, err := client.NewKubeletConfigClient(clusters).Exists(context.Background(), "123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+})
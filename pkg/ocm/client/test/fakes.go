@@ -0,0 +1,444 @@
+package test
+
+// Stateful in-memory implementations of the OCM clients. Instead of scripting each call like with the mocks, tests can
+// seed the fakes with some objects and then assert on the objects stored at the end of the test.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift-online/ocm-common/pkg/ocm/client"
+)
+
+// Operation identifies the OCM request sent by a client method, so that errors can be injected with SetError
+type Operation string
+
+const (
+	// OperationGet - the request sent by Get and Exists
+	OperationGet Operation = "Get"
+	// OperationCreate - the request sent by Create
+	OperationCreate Operation = "Create"
+	// OperationUpdate - the request sent by Update
+	OperationUpdate Operation = "Update"
+	// OperationDelete - the request sent by Delete
+	OperationDelete Operation = "Delete"
+	// OperationList - the request sent by List, also for each page requested by client.ListAll and client.All
+	OperationList Operation = "List"
+)
+
+// NewOcmError creates an error like the ones returned by the clients for the given HTTP status: a *client.OcmError
+// wrapping the error of the OCM SDK
+func NewOcmError(status int, reason string) error {
+	err, buildErr := errors.NewError().
+		Status(status).
+		Code(fmt.Sprintf("CLUSTERS-MGMT-%d", status)).
+		Reason(reason).
+		Build()
+	if buildErr != nil {
+		return buildErr
+	}
+	return client.WrapError(nil, err)
+}
+
+var (
+	_ client.MachinePoolClient           = (*FakeCollectionClusterSubResource[v1.MachinePool])(nil)
+	_ client.NodePoolClient              = (*FakeCollectionClusterSubResource[v1.NodePool])(nil)
+	_ client.KubeletConfigsClient        = (*FakeCollectionClusterSubResource[v1.KubeletConfig])(nil)
+	_ client.IdentityProviderClient      = (*FakeCollectionClusterSubResource[v1.IdentityProvider])(nil)
+	_ client.IngressClient               = (*FakeCollectionClusterSubResource[v1.Ingress])(nil)
+	_ client.UpgradePolicyClient         = (*FakeCollectionClusterSubResource[v1.UpgradePolicy])(nil)
+	_ client.NodePoolUpgradePolicyClient = (*FakeCollectionClusterSubResource[v1.NodePoolUpgradePolicy])(nil)
+	_ client.ExternalAuthClient          = (*FakeCollectionClusterSubResource[v1.ExternalAuth])(nil)
+	_ client.KubeletConfigClient         = (*FakeSingleClusterSubResource[v1.KubeletConfig])(nil)
+	_ client.ClusterAutoscalerClient     = (*FakeSingleClusterSubResource[v1.ClusterAutoscaler])(nil)
+)
+
+// injectedErrors stores the errors configured with SetError
+type injectedErrors struct {
+	errors map[Operation]error
+}
+
+// get returns the error injected for the operation, converted like the clients do for the errors of the OCM SDK
+func (e *injectedErrors) get(operation Operation) error {
+	return client.WrapError(nil, e.errors[operation])
+}
+
+func (e *injectedErrors) set(operation Operation, err error) {
+	if e.errors == nil {
+		e.errors = map[Operation]error{}
+	}
+	if err == nil {
+		delete(e.errors, operation)
+		return
+	}
+	e.errors[operation] = err
+}
+
+// FakeCollectionClusterSubResource is a stateful in-memory implementation of client.CollectionClusterSubResource.
+// The instances are stored per cluster in creation order. Update replaces the stored instance with the received one.
+type FakeCollectionClusterSubResource[T any] struct {
+	lock      sync.Mutex
+	kind      string
+	instances map[string][]*T
+	injected  injectedErrors
+	lastId    int
+
+	idOf   func(instance *T) string
+	withId func(instance *T, id string) (*T, error)
+}
+
+// NewFakeCollectionClusterSubResource creates an empty fake. idOf returns the ID of an instance, withId returns a copy of
+// the instance with the given ID and is used by Create to assign an ID to the instances without one.
+func NewFakeCollectionClusterSubResource[T any](kind string, idOf func(instance *T) string, withId func(instance *T, id string) (*T, error)) *FakeCollectionClusterSubResource[T] {
+	return &FakeCollectionClusterSubResource[T]{
+		kind:      kind,
+		instances: map[string][]*T{},
+		idOf:      idOf,
+		withId:    withId,
+	}
+}
+
+// NewFakeMachinePoolClient creates an empty fake client.MachinePoolClient
+func NewFakeMachinePoolClient() *FakeCollectionClusterSubResource[v1.MachinePool] {
+	return NewFakeCollectionClusterSubResource("Machine pool",
+		func(instance *v1.MachinePool) string { return instance.ID() },
+		func(instance *v1.MachinePool, id string) (*v1.MachinePool, error) {
+			return v1.NewMachinePool().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeNodePoolClient creates an empty fake client.NodePoolClient
+func NewFakeNodePoolClient() *FakeCollectionClusterSubResource[v1.NodePool] {
+	return NewFakeCollectionClusterSubResource("Node pool",
+		func(instance *v1.NodePool) string { return instance.ID() },
+		func(instance *v1.NodePool, id string) (*v1.NodePool, error) {
+			return v1.NewNodePool().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeKubeletConfigsClient creates an empty fake client.KubeletConfigsClient
+func NewFakeKubeletConfigsClient() *FakeCollectionClusterSubResource[v1.KubeletConfig] {
+	return NewFakeCollectionClusterSubResource("KubeletConfig",
+		func(instance *v1.KubeletConfig) string { return instance.ID() },
+		func(instance *v1.KubeletConfig, id string) (*v1.KubeletConfig, error) {
+			return v1.NewKubeletConfig().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeIdentityProviderClient creates an empty fake client.IdentityProviderClient
+func NewFakeIdentityProviderClient() *FakeCollectionClusterSubResource[v1.IdentityProvider] {
+	return NewFakeCollectionClusterSubResource("Identity provider",
+		func(instance *v1.IdentityProvider) string { return instance.ID() },
+		func(instance *v1.IdentityProvider, id string) (*v1.IdentityProvider, error) {
+			return v1.NewIdentityProvider().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeIngressClient creates an empty fake client.IngressClient
+func NewFakeIngressClient() *FakeCollectionClusterSubResource[v1.Ingress] {
+	return NewFakeCollectionClusterSubResource("Ingress",
+		func(instance *v1.Ingress) string { return instance.ID() },
+		func(instance *v1.Ingress, id string) (*v1.Ingress, error) {
+			return v1.NewIngress().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeUpgradePolicyClient creates an empty fake client.UpgradePolicyClient
+func NewFakeUpgradePolicyClient() *FakeCollectionClusterSubResource[v1.UpgradePolicy] {
+	return NewFakeCollectionClusterSubResource("Upgrade policy",
+		func(instance *v1.UpgradePolicy) string { return instance.ID() },
+		func(instance *v1.UpgradePolicy, id string) (*v1.UpgradePolicy, error) {
+			return v1.NewUpgradePolicy().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeNodePoolUpgradePolicyClient creates an empty fake client.NodePoolUpgradePolicyClient
+func NewFakeNodePoolUpgradePolicyClient() *FakeCollectionClusterSubResource[v1.NodePoolUpgradePolicy] {
+	return NewFakeCollectionClusterSubResource("Node pool upgrade policy",
+		func(instance *v1.NodePoolUpgradePolicy) string { return instance.ID() },
+		func(instance *v1.NodePoolUpgradePolicy, id string) (*v1.NodePoolUpgradePolicy, error) {
+			return v1.NewNodePoolUpgradePolicy().Copy(instance).ID(id).Build()
+		})
+}
+
+// NewFakeExternalAuthClient creates an empty fake client.ExternalAuthClient
+func NewFakeExternalAuthClient() *FakeCollectionClusterSubResource[v1.ExternalAuth] {
+	return NewFakeCollectionClusterSubResource("External auth",
+		func(instance *v1.ExternalAuth) string { return instance.ID() },
+		func(instance *v1.ExternalAuth, id string) (*v1.ExternalAuth, error) {
+			return v1.NewExternalAuth().Copy(instance).ID(id).Build()
+		})
+}
+
+// Add stores the instances without going through Create, replacing the ones with the same ID. Useful to seed the fake.
+func (f *FakeCollectionClusterSubResource[T]) Add(clusterId string, instances ...*T) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, instance := range instances {
+		if i := f.indexOf(clusterId, f.idOf(instance)); i >= 0 {
+			f.instances[clusterId][i] = instance
+		} else {
+			f.instances[clusterId] = append(f.instances[clusterId], instance)
+		}
+	}
+}
+
+// Instances returns the instances stored for the cluster, in creation order
+func (f *FakeCollectionClusterSubResource[T]) Instances(clusterId string) []*T {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]*T{}, f.instances[clusterId]...)
+}
+
+// SetError makes the requests of the given operation fail with the received error until it is set again to nil
+func (f *FakeCollectionClusterSubResource[T]) SetError(operation Operation, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.injected.set(operation, err)
+}
+
+func (f *FakeCollectionClusterSubResource[T]) indexOf(clusterId string, instanceId string) int {
+	for i, instance := range f.instances[clusterId] {
+		if f.idOf(instance) == instanceId {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *FakeCollectionClusterSubResource[T]) notFound(instanceId string) error {
+	return NewOcmError(http.StatusNotFound, fmt.Sprintf("%s '%s' not found", f.kind, instanceId))
+}
+
+func (f *FakeCollectionClusterSubResource[T]) Get(_ context.Context, clusterId string, instanceId string) (*T, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationGet); err != nil {
+		return nil, err
+	}
+	i := f.indexOf(clusterId, instanceId)
+	if i < 0 {
+		return nil, f.notFound(instanceId)
+	}
+	return f.instances[clusterId][i], nil
+}
+
+func (f *FakeCollectionClusterSubResource[T]) Exists(ctx context.Context, clusterId string, instanceId string) (bool, *T, error) {
+	instance, err := f.Get(ctx, clusterId, instanceId)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, instance, nil
+}
+
+func (f *FakeCollectionClusterSubResource[T]) Create(_ context.Context, clusterId string, instance *T) (*T, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationCreate); err != nil {
+		return nil, err
+	}
+
+	id := f.idOf(instance)
+	if id == "" {
+		// like OCM, generate the missing IDs
+		f.lastId++
+		id = strconv.Itoa(f.lastId)
+		var err error
+		if instance, err = f.withId(instance, id); err != nil {
+			return nil, err
+		}
+	}
+	if f.indexOf(clusterId, id) >= 0 {
+		return nil, NewOcmError(http.StatusConflict, fmt.Sprintf("%s '%s' already exists", f.kind, id))
+	}
+	f.instances[clusterId] = append(f.instances[clusterId], instance)
+	return instance, nil
+}
+
+func (f *FakeCollectionClusterSubResource[T]) Update(_ context.Context, clusterId string, instance *T) (*T, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationUpdate); err != nil {
+		return nil, err
+	}
+	i := f.indexOf(clusterId, f.idOf(instance))
+	if i < 0 {
+		return nil, f.notFound(f.idOf(instance))
+	}
+	f.instances[clusterId][i] = instance
+	return instance, nil
+}
+
+func (f *FakeCollectionClusterSubResource[T]) Delete(_ context.Context, clusterId string, instanceId string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationDelete); err != nil {
+		return err
+	}
+	i := f.indexOf(clusterId, instanceId)
+	if i < 0 {
+		return f.notFound(instanceId)
+	}
+	instances := f.instances[clusterId]
+	f.instances[clusterId] = append(instances[:i:i], instances[i+1:]...)
+	return nil
+}
+
+// List returns the requested page. Like OCM, the page number defaults to 1 and the size to 100.
+func (f *FakeCollectionClusterSubResource[T]) List(_ context.Context, clusterId string, paging client.Paging) ([]*T, bool, error) {
+	items, _, err := f.list(clusterId, paging)
+	if err != nil {
+		return make([]*T, 0), false, err
+	}
+	return items, len(items) != 0, nil
+}
+
+func (f *FakeCollectionClusterSubResource[T]) list(clusterId string, paging client.Paging) ([]*T, int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationList); err != nil {
+		return nil, 0, err
+	}
+
+	page, size := paging.Page(), paging.Size()
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = client.DefaultPageSize
+	}
+	instances := f.instances[clusterId]
+	start := (page - 1) * size
+	if start >= len(instances) {
+		return make([]*T, 0), len(instances), nil
+	}
+	end := start + size
+	if end > len(instances) {
+		end = len(instances)
+	}
+	return append([]*T{}, instances[start:end]...), len(instances), nil
+}
+
+// FakeSingleClusterSubResource is a stateful in-memory implementation of client.SingleClusterSubResource, storing at
+// most one instance per cluster. Update replaces the stored instance with the received one.
+type FakeSingleClusterSubResource[T any] struct {
+	lock      sync.Mutex
+	kind      string
+	instances map[string]*T
+	injected  injectedErrors
+}
+
+// NewFakeSingleClusterSubResource creates an empty fake
+func NewFakeSingleClusterSubResource[T any](kind string) *FakeSingleClusterSubResource[T] {
+	return &FakeSingleClusterSubResource[T]{
+		kind:      kind,
+		instances: map[string]*T{},
+	}
+}
+
+// NewFakeKubeletConfigClient creates an empty fake client.KubeletConfigClient
+func NewFakeKubeletConfigClient() *FakeSingleClusterSubResource[v1.KubeletConfig] {
+	return NewFakeSingleClusterSubResource[v1.KubeletConfig]("KubeletConfig")
+}
+
+// NewFakeClusterAutoscalerClient creates an empty fake client.ClusterAutoscalerClient
+func NewFakeClusterAutoscalerClient() *FakeSingleClusterSubResource[v1.ClusterAutoscaler] {
+	return NewFakeSingleClusterSubResource[v1.ClusterAutoscaler]("Autoscaler")
+}
+
+// Add stores the instance without going through Create, replacing the existing one. Useful to seed the fake.
+func (f *FakeSingleClusterSubResource[T]) Add(clusterId string, instance *T) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.instances[clusterId] = instance
+}
+
+// Instance returns the instance stored for the cluster, nil if there isn't one
+func (f *FakeSingleClusterSubResource[T]) Instance(clusterId string) *T {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.instances[clusterId]
+}
+
+// SetError makes the requests of the given operation fail with the received error until it is set again to nil
+func (f *FakeSingleClusterSubResource[T]) SetError(operation Operation, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.injected.set(operation, err)
+}
+
+func (f *FakeSingleClusterSubResource[T]) notFound(clusterId string) error {
+	return NewOcmError(http.StatusNotFound, fmt.Sprintf("%s for cluster '%s' not found", f.kind, clusterId))
+}
+
+func (f *FakeSingleClusterSubResource[T]) Get(_ context.Context, clusterId string) (*T, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationGet); err != nil {
+		return nil, err
+	}
+	instance, ok := f.instances[clusterId]
+	if !ok {
+		return nil, f.notFound(clusterId)
+	}
+	return instance, nil
+}
+
+func (f *FakeSingleClusterSubResource[T]) Exists(ctx context.Context, clusterId string) (bool, *T, error) {
+	instance, err := f.Get(ctx, clusterId)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, instance, nil
+}
+
+func (f *FakeSingleClusterSubResource[T]) Create(_ context.Context, clusterId string, instance *T) (*T, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationCreate); err != nil {
+		return nil, err
+	}
+	if _, ok := f.instances[clusterId]; ok {
+		return nil, NewOcmError(http.StatusConflict, fmt.Sprintf("%s for cluster '%s' already exists", f.kind, clusterId))
+	}
+	f.instances[clusterId] = instance
+	return instance, nil
+}
+
+func (f *FakeSingleClusterSubResource[T]) Update(_ context.Context, clusterId string, instance *T) (*T, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationUpdate); err != nil {
+		return nil, err
+	}
+	if _, ok := f.instances[clusterId]; !ok {
+		return nil, f.notFound(clusterId)
+	}
+	f.instances[clusterId] = instance
+	return instance, nil
+}
+
+func (f *FakeSingleClusterSubResource[T]) Delete(_ context.Context, clusterId string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := f.injected.get(OperationDelete); err != nil {
+		return err
+	}
+	if _, ok := f.instances[clusterId]; !ok {
+		return f.notFound(clusterId)
+	}
+	delete(f.instances, clusterId)
+	return nil
+}
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	sdkerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift-online/ocm-common/pkg/ocm/client"
+)
+
+var _ = Describe("Fakes", func() {
+	ctx := context.Background()
+
+	newMachinePool := func(id string) *v1.MachinePool {
+		machinePool, err := NewMachinePool(func(k *v1.MachinePoolBuilder) {
+			k.ID(id).InstanceType("m5.xlarge")
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return machinePool
+	}
+
+	expectStatus := func(err error, status int) {
+		var ocmError *sdkerrors.Error
+		Expect(errors.As(err, &ocmError)).To(BeTrue())
+		Expect(ocmError.Status()).To(Equal(status))
+	}
+
+	Context("Collection clients", func() {
+		var machinePools client.MachinePoolClient
+		var fake *FakeCollectionClusterSubResource[v1.MachinePool]
+
+		BeforeEach(func() {
+			fake = NewFakeMachinePoolClient()
+			machinePools = fake
+		})
+
+		It("Stores the instances per cluster", func() {
+			created, err := machinePools.Create(ctx, "cluster-a", newMachinePool("workers"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ID()).To(Equal("workers"))
+
+			exists, machinePool, err := machinePools.Exists(ctx, "cluster-a", "workers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(machinePool.InstanceType()).To(Equal("m5.xlarge"))
+
+			exists, machinePool, err = machinePools.Exists(ctx, "cluster-b", "workers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+			Expect(machinePool).To(BeNil())
+
+			_, err = machinePools.Get(ctx, "cluster-b", "workers")
+			expectStatus(err, http.StatusNotFound)
+		})
+
+		It("Refuses duplicated IDs and generates the missing ones", func() {
+			fake.Add("cluster-a", newMachinePool("workers"))
+
+			_, err := machinePools.Create(ctx, "cluster-a", newMachinePool("workers"))
+			expectStatus(err, http.StatusConflict)
+
+			created, err := machinePools.Create(ctx, "cluster-a", newMachinePool(""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ID()).NotTo(BeEmpty())
+			Expect(created.InstanceType()).To(Equal("m5.xlarge"))
+			Expect(fake.Instances("cluster-a")).To(HaveLen(2))
+		})
+
+		It("Updates and deletes the instances", func() {
+			fake.Add("cluster-a", newMachinePool("workers"))
+
+			updated, err := v1.NewMachinePool().ID("workers").InstanceType("m5.2xlarge").Build()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = machinePools.Update(ctx, "cluster-a", updated)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fake.Instances("cluster-a")[0].InstanceType()).To(Equal("m5.2xlarge"))
+
+			Expect(machinePools.Delete(ctx, "cluster-a", "workers")).To(Succeed())
+			Expect(fake.Instances("cluster-a")).To(BeEmpty())
+
+			expectStatus(machinePools.Delete(ctx, "cluster-a", "workers"), http.StatusNotFound)
+			_, err = machinePools.Update(ctx, "cluster-a", updated)
+			expectStatus(err, http.StatusNotFound)
+		})
+
+		It("Pages the instances", func() {
+			for i := 0; i < 250; i++ {
+				fake.Add("cluster-a", newMachinePool(fmt.Sprintf("pool-%d", i)))
+			}
+
+			items, hasItems, err := machinePools.List(ctx, "cluster-a", client.NewPaging(2, 10))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasItems).To(BeTrue())
+			Expect(items).To(HaveLen(10))
+			Expect(items[0].ID()).To(Equal("pool-10"))
+
+			items, hasItems, err = machinePools.List(ctx, "cluster-a", client.NewPaging(3, 100))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasItems).To(BeTrue())
+			Expect(items).To(HaveLen(50))
+
+			_, hasItems, err = machinePools.List(ctx, "cluster-a", client.NewPaging(4, 100))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasItems).To(BeFalse())
+
+			all, err := client.ListAll(ctx, machinePools, "cluster-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(all).To(HaveLen(250))
+		})
+
+		It("Returns the injected errors", func() {
+			fake.Add("cluster-a", newMachinePool("workers"))
+			throttled := NewOcmError(http.StatusTooManyRequests, "slow down")
+
+			fake.SetError(OperationGet, throttled)
+			_, _, err := machinePools.Exists(ctx, "cluster-a", "workers")
+			Expect(err).To(Equal(throttled))
+
+			fake.SetError(OperationList, throttled)
+			_, err = client.ListAll(ctx, machinePools, "cluster-a")
+			Expect(err).To(Equal(throttled))
+
+			fake.SetError(OperationGet, nil)
+			exists, _, err := machinePools.Exists(ctx, "cluster-a", "workers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+	})
+
+	Context("Single clients", func() {
+		It("Stores one instance per cluster", func() {
+			fake := NewFakeClusterAutoscalerClient()
+			var autoscalers client.ClusterAutoscalerClient = fake
+
+			exists, _, err := autoscalers.Exists(ctx, "cluster-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			autoscaler, err := NewClusterAutoscaler(func(k *v1.ClusterAutoscalerBuilder) {
+				k.LogVerbosity(3)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = autoscalers.Create(ctx, "cluster-a", autoscaler)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = autoscalers.Create(ctx, "cluster-a", autoscaler)
+			expectStatus(err, http.StatusConflict)
+
+			exists, stored, err := autoscalers.Exists(ctx, "cluster-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(stored.LogVerbosity()).To(Equal(3))
+
+			Expect(autoscalers.Delete(ctx, "cluster-a")).To(Succeed())
+			Expect(fake.Instance("cluster-a")).To(BeNil())
+			expectStatus(autoscalers.Delete(ctx, "cluster-a"), http.StatusNotFound)
+		})
+
+		It("Returns the injected errors", func() {
+			fake := NewFakeKubeletConfigClient()
+			kubeletConfig, err := NewKubeletConfig()
+			Expect(err).NotTo(HaveOccurred())
+			fake.Add("cluster-a", kubeletConfig)
+
+			fake.SetError(OperationUpdate, NewOcmError(http.StatusBadRequest, "invalid"))
+			_, err = fake.Update(ctx, "cluster-a", kubeletConfig)
+			expectStatus(err, http.StatusBadRequest)
+		})
+	})
+})
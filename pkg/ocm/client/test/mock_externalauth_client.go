@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: externalauth_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=externalauth_client.go -package=test -destination=test/mock_externalauth_client.go
+//
+// Package test is a generated GoMock package.
+package test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	client "github.com/openshift-online/ocm-common/pkg/ocm/client"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExternalAuthClient is a mock of ExternalAuthClient interface.
+type MockExternalAuthClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalAuthClientMockRecorder
+}
+
+// MockExternalAuthClientMockRecorder is the mock recorder for MockExternalAuthClient.
+type MockExternalAuthClientMockRecorder struct {
+	mock *MockExternalAuthClient
+}
+
+// NewMockExternalAuthClient creates a new mock instance.
+func NewMockExternalAuthClient(ctrl *gomock.Controller) *MockExternalAuthClient {
+	mock := &MockExternalAuthClient{ctrl: ctrl}
+	mock.recorder = &MockExternalAuthClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalAuthClient) EXPECT() *MockExternalAuthClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExternalAuthClient) Create(ctx context.Context, clusterId string, instance *v1.ExternalAuth) (*v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.ExternalAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExternalAuthClientMockRecorder) Create(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExternalAuthClient)(nil).Create), ctx, clusterId, instance)
+}
+
+// Delete mocks base method.
+func (m *MockExternalAuthClient) Delete(ctx context.Context, clusterId, instanceId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExternalAuthClientMockRecorder) Delete(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExternalAuthClient)(nil).Delete), ctx, clusterId, instanceId)
+}
+
+// Exists mocks base method.
+func (m *MockExternalAuthClient) Exists(ctx context.Context, clusterId, instanceId string) (bool, *v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.ExternalAuth)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockExternalAuthClientMockRecorder) Exists(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockExternalAuthClient)(nil).Exists), ctx, clusterId, instanceId)
+}
+
+// Get mocks base method.
+func (m *MockExternalAuthClient) Get(ctx context.Context, clusterId, instanceId string) (*v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(*v1.ExternalAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExternalAuthClientMockRecorder) Get(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExternalAuthClient)(nil).Get), ctx, clusterId, instanceId)
+}
+
+// List mocks base method.
+func (m *MockExternalAuthClient) List(ctx context.Context, clusterId string, paging client.Paging) ([]*v1.ExternalAuth, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId, paging)
+	ret0, _ := ret[0].([]*v1.ExternalAuth)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockExternalAuthClientMockRecorder) List(ctx, clusterId, paging any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockExternalAuthClient)(nil).List), ctx, clusterId, paging)
+}
+
+// Update mocks base method.
+func (m *MockExternalAuthClient) Update(ctx context.Context, clusterId string, instance *v1.ExternalAuth) (*v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.ExternalAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExternalAuthClientMockRecorder) Update(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExternalAuthClient)(nil).Update), ctx, clusterId, instance)
+}
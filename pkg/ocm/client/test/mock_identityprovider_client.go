@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: identityprovider_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=identityprovider_client.go -package=test -destination=test/mock_identityprovider_client.go
+//
+// Package test is a generated GoMock package.
+package test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	client "github.com/openshift-online/ocm-common/pkg/ocm/client"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdentityProviderClient is a mock of IdentityProviderClient interface.
+type MockIdentityProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityProviderClientMockRecorder
+}
+
+// MockIdentityProviderClientMockRecorder is the mock recorder for MockIdentityProviderClient.
+type MockIdentityProviderClientMockRecorder struct {
+	mock *MockIdentityProviderClient
+}
+
+// NewMockIdentityProviderClient creates a new mock instance.
+func NewMockIdentityProviderClient(ctrl *gomock.Controller) *MockIdentityProviderClient {
+	mock := &MockIdentityProviderClient{ctrl: ctrl}
+	mock.recorder = &MockIdentityProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityProviderClient) EXPECT() *MockIdentityProviderClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIdentityProviderClient) Create(ctx context.Context, clusterId string, instance *v1.IdentityProvider) (*v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdentityProviderClientMockRecorder) Create(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdentityProviderClient)(nil).Create), ctx, clusterId, instance)
+}
+
+// Delete mocks base method.
+func (m *MockIdentityProviderClient) Delete(ctx context.Context, clusterId, instanceId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdentityProviderClientMockRecorder) Delete(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdentityProviderClient)(nil).Delete), ctx, clusterId, instanceId)
+}
+
+// Exists mocks base method.
+func (m *MockIdentityProviderClient) Exists(ctx context.Context, clusterId, instanceId string) (bool, *v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.IdentityProvider)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockIdentityProviderClientMockRecorder) Exists(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockIdentityProviderClient)(nil).Exists), ctx, clusterId, instanceId)
+}
+
+// Get mocks base method.
+func (m *MockIdentityProviderClient) Get(ctx context.Context, clusterId, instanceId string) (*v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(*v1.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdentityProviderClientMockRecorder) Get(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdentityProviderClient)(nil).Get), ctx, clusterId, instanceId)
+}
+
+// List mocks base method.
+func (m *MockIdentityProviderClient) List(ctx context.Context, clusterId string, paging client.Paging) ([]*v1.IdentityProvider, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId, paging)
+	ret0, _ := ret[0].([]*v1.IdentityProvider)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockIdentityProviderClientMockRecorder) List(ctx, clusterId, paging any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIdentityProviderClient)(nil).List), ctx, clusterId, paging)
+}
+
+// Update mocks base method.
+func (m *MockIdentityProviderClient) Update(ctx context.Context, clusterId string, instance *v1.IdentityProvider) (*v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIdentityProviderClientMockRecorder) Update(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIdentityProviderClient)(nil).Update), ctx, clusterId, instance)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingress_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ingress_client.go -package=test -destination=test/mock_ingress_client.go
+//
+// Package test is a generated GoMock package.
+package test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	client "github.com/openshift-online/ocm-common/pkg/ocm/client"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIngressClient is a mock of IngressClient interface.
+type MockIngressClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngressClientMockRecorder
+}
+
+// MockIngressClientMockRecorder is the mock recorder for MockIngressClient.
+type MockIngressClientMockRecorder struct {
+	mock *MockIngressClient
+}
+
+// NewMockIngressClient creates a new mock instance.
+func NewMockIngressClient(ctrl *gomock.Controller) *MockIngressClient {
+	mock := &MockIngressClient{ctrl: ctrl}
+	mock.recorder = &MockIngressClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngressClient) EXPECT() *MockIngressClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIngressClient) Create(ctx context.Context, clusterId string, instance *v1.Ingress) (*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIngressClientMockRecorder) Create(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIngressClient)(nil).Create), ctx, clusterId, instance)
+}
+
+// Delete mocks base method.
+func (m *MockIngressClient) Delete(ctx context.Context, clusterId, instanceId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIngressClientMockRecorder) Delete(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIngressClient)(nil).Delete), ctx, clusterId, instanceId)
+}
+
+// Exists mocks base method.
+func (m *MockIngressClient) Exists(ctx context.Context, clusterId, instanceId string) (bool, *v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.Ingress)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockIngressClientMockRecorder) Exists(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockIngressClient)(nil).Exists), ctx, clusterId, instanceId)
+}
+
+// Get mocks base method.
+func (m *MockIngressClient) Get(ctx context.Context, clusterId, instanceId string) (*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngressClientMockRecorder) Get(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngressClient)(nil).Get), ctx, clusterId, instanceId)
+}
+
+// List mocks base method.
+func (m *MockIngressClient) List(ctx context.Context, clusterId string, paging client.Paging) ([]*v1.Ingress, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId, paging)
+	ret0, _ := ret[0].([]*v1.Ingress)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockIngressClientMockRecorder) List(ctx, clusterId, paging any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngressClient)(nil).List), ctx, clusterId, paging)
+}
+
+// Update mocks base method.
+func (m *MockIngressClient) Update(ctx context.Context, clusterId string, instance *v1.Ingress) (*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIngressClientMockRecorder) Update(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngressClient)(nil).Update), ctx, clusterId, instance)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: nodepoolupgradepolicy_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=nodepoolupgradepolicy_client.go -package=test -destination=test/mock_nodepoolupgradepolicy_client.go
+//
+// Package test is a generated GoMock package.
+package test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	client "github.com/openshift-online/ocm-common/pkg/ocm/client"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNodePoolUpgradePolicyClient is a mock of NodePoolUpgradePolicyClient interface.
+type MockNodePoolUpgradePolicyClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodePoolUpgradePolicyClientMockRecorder
+}
+
+// MockNodePoolUpgradePolicyClientMockRecorder is the mock recorder for MockNodePoolUpgradePolicyClient.
+type MockNodePoolUpgradePolicyClientMockRecorder struct {
+	mock *MockNodePoolUpgradePolicyClient
+}
+
+// NewMockNodePoolUpgradePolicyClient creates a new mock instance.
+func NewMockNodePoolUpgradePolicyClient(ctrl *gomock.Controller) *MockNodePoolUpgradePolicyClient {
+	mock := &MockNodePoolUpgradePolicyClient{ctrl: ctrl}
+	mock.recorder = &MockNodePoolUpgradePolicyClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodePoolUpgradePolicyClient) EXPECT() *MockNodePoolUpgradePolicyClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNodePoolUpgradePolicyClient) Create(ctx context.Context, clusterId string, instance *v1.NodePoolUpgradePolicy) (*v1.NodePoolUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.NodePoolUpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNodePoolUpgradePolicyClientMockRecorder) Create(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNodePoolUpgradePolicyClient)(nil).Create), ctx, clusterId, instance)
+}
+
+// Delete mocks base method.
+func (m *MockNodePoolUpgradePolicyClient) Delete(ctx context.Context, clusterId, instanceId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNodePoolUpgradePolicyClientMockRecorder) Delete(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNodePoolUpgradePolicyClient)(nil).Delete), ctx, clusterId, instanceId)
+}
+
+// Exists mocks base method.
+func (m *MockNodePoolUpgradePolicyClient) Exists(ctx context.Context, clusterId, instanceId string) (bool, *v1.NodePoolUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.NodePoolUpgradePolicy)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockNodePoolUpgradePolicyClientMockRecorder) Exists(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockNodePoolUpgradePolicyClient)(nil).Exists), ctx, clusterId, instanceId)
+}
+
+// Get mocks base method.
+func (m *MockNodePoolUpgradePolicyClient) Get(ctx context.Context, clusterId, instanceId string) (*v1.NodePoolUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(*v1.NodePoolUpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNodePoolUpgradePolicyClientMockRecorder) Get(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNodePoolUpgradePolicyClient)(nil).Get), ctx, clusterId, instanceId)
+}
+
+// List mocks base method.
+func (m *MockNodePoolUpgradePolicyClient) List(ctx context.Context, clusterId string, paging client.Paging) ([]*v1.NodePoolUpgradePolicy, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId, paging)
+	ret0, _ := ret[0].([]*v1.NodePoolUpgradePolicy)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockNodePoolUpgradePolicyClientMockRecorder) List(ctx, clusterId, paging any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNodePoolUpgradePolicyClient)(nil).List), ctx, clusterId, paging)
+}
+
+// Update mocks base method.
+func (m *MockNodePoolUpgradePolicyClient) Update(ctx context.Context, clusterId string, instance *v1.NodePoolUpgradePolicy) (*v1.NodePoolUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.NodePoolUpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNodePoolUpgradePolicyClientMockRecorder) Update(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNodePoolUpgradePolicyClient)(nil).Update), ctx, clusterId, instance)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: upgradepolicy_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=upgradepolicy_client.go -package=test -destination=test/mock_upgradepolicy_client.go
+//
+// Package test is a generated GoMock package.
+package test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	client "github.com/openshift-online/ocm-common/pkg/ocm/client"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUpgradePolicyClient is a mock of UpgradePolicyClient interface.
+type MockUpgradePolicyClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpgradePolicyClientMockRecorder
+}
+
+// MockUpgradePolicyClientMockRecorder is the mock recorder for MockUpgradePolicyClient.
+type MockUpgradePolicyClientMockRecorder struct {
+	mock *MockUpgradePolicyClient
+}
+
+// NewMockUpgradePolicyClient creates a new mock instance.
+func NewMockUpgradePolicyClient(ctrl *gomock.Controller) *MockUpgradePolicyClient {
+	mock := &MockUpgradePolicyClient{ctrl: ctrl}
+	mock.recorder = &MockUpgradePolicyClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpgradePolicyClient) EXPECT() *MockUpgradePolicyClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUpgradePolicyClient) Create(ctx context.Context, clusterId string, instance *v1.UpgradePolicy) (*v1.UpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.UpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUpgradePolicyClientMockRecorder) Create(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUpgradePolicyClient)(nil).Create), ctx, clusterId, instance)
+}
+
+// Delete mocks base method.
+func (m *MockUpgradePolicyClient) Delete(ctx context.Context, clusterId, instanceId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUpgradePolicyClientMockRecorder) Delete(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUpgradePolicyClient)(nil).Delete), ctx, clusterId, instanceId)
+}
+
+// Exists mocks base method.
+func (m *MockUpgradePolicyClient) Exists(ctx context.Context, clusterId, instanceId string) (bool, *v1.UpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.UpgradePolicy)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockUpgradePolicyClientMockRecorder) Exists(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockUpgradePolicyClient)(nil).Exists), ctx, clusterId, instanceId)
+}
+
+// Get mocks base method.
+func (m *MockUpgradePolicyClient) Get(ctx context.Context, clusterId, instanceId string) (*v1.UpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, instanceId)
+	ret0, _ := ret[0].(*v1.UpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUpgradePolicyClientMockRecorder) Get(ctx, clusterId, instanceId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUpgradePolicyClient)(nil).Get), ctx, clusterId, instanceId)
+}
+
+// List mocks base method.
+func (m *MockUpgradePolicyClient) List(ctx context.Context, clusterId string, paging client.Paging) ([]*v1.UpgradePolicy, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId, paging)
+	ret0, _ := ret[0].([]*v1.UpgradePolicy)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockUpgradePolicyClientMockRecorder) List(ctx, clusterId, paging any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUpgradePolicyClient)(nil).List), ctx, clusterId, paging)
+}
+
+// Update mocks base method.
+func (m *MockUpgradePolicyClient) Update(ctx context.Context, clusterId string, instance *v1.UpgradePolicy) (*v1.UpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, instance)
+	ret0, _ := ret[0].(*v1.UpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUpgradePolicyClientMockRecorder) Update(ctx, clusterId, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUpgradePolicyClient)(nil).Update), ctx, clusterId, instance)
+}
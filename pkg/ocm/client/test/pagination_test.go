@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift-online/ocm-common/pkg/ocm/client"
+)
+
+var _ = Describe("Pagination", func() {
+	var clusters *v1.ClustersClient
+	// responses - the bodies of the responses, in order
+	var responses []string
+	var status int
+	var requests []string
+
+	BeforeEach(func() {
+		responses = nil
+		status = http.StatusOK
+		requests = nil
+		clusters = v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			requests = append(requests, request.URL.Path+"?"+request.URL.RawQuery)
+			Expect(responses).NotTo(BeEmpty(), "unexpected request %s", request.URL)
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("Content-Type", "application/json")
+			recorder.WriteHeader(status)
+			_, _ = recorder.WriteString(responses[0])
+			responses = responses[1:]
+			return recorder.Result(), nil
+		}), "/api/clusters_mgmt/v1/clusters")
+	})
+
+	page := func(page int, size int, total int, ids ...string) string {
+		items := make([]string, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, fmt.Sprintf(`{"id":%q}`, id))
+		}
+		return fmt.Sprintf(`{"page":%d,"size":%d,"total":%d,"items":[%s]}`, page, size, total, strings.Join(items, ","))
+	}
+
+	machinePoolIds := func(machinePools []*v1.MachinePool) []string {
+		ids := make([]string, 0, len(machinePools))
+		for _, machinePool := range machinePools {
+			ids = append(ids, machinePool.ID())
+		}
+		return ids
+	}
+
+	It("Lists all the pages using the total", func() {
+		responses = []string{page(1, 2, 3, "a", "b"), page(2, 1, 3, "c")}
+
+		machinePools, err := client.ListAll(context.Background(), client.NewMachinePoolClient(clusters), "123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePoolIds(machinePools)).To(Equal([]string{"a", "b", "c"}))
+		Expect(requests).To(Equal([]string{
+			"/api/clusters_mgmt/v1/clusters/123/machine_pools?page=1&size=100",
+			"/api/clusters_mgmt/v1/clusters/123/machine_pools?page=2&size=100",
+		}))
+	})
+
+	It("Stops at a page smaller than the requested size when the total is unknown", func() {
+		responses = []string{page(1, 2, 0, "a", "b")}
+
+		nodePools, err := client.ListAll(context.Background(), client.NewNodePoolClient(clusters), "123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePools).To(HaveLen(2))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("Stops at the first empty page", func() {
+		responses = []string{page(1, 0, 0)}
+
+		nodePools, err := client.ListAll(context.Background(), client.NewNodePoolClient(clusters), "123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePools).To(BeEmpty())
+		Expect(requests).To(Equal([]string{"/api/clusters_mgmt/v1/clusters/123/node_pools?page=1&size=100"}))
+	})
+
+	It("Iterates lazily", func() {
+		responses = []string{page(1, 2, 4, "a", "b"), page(2, 2, 4, "c", "d")}
+
+		var ids []string
+		client.All(context.Background(), client.NewKubeletConfigsClient(clusters), "123")(
+			func(kubeletConfig *v1.KubeletConfig, err error) bool {
+				Expect(err).NotTo(HaveOccurred())
+				ids = append(ids, kubeletConfig.ID())
+				return len(ids) < 2
+			})
+		Expect(ids).To(Equal([]string{"a", "b"}))
+		Expect(requests).To(Equal([]string{"/api/clusters_mgmt/v1/clusters/123/kubelet_configs?page=1&size=100"}))
+	})
+
+	It("Returns the errors", func() {
+		status = http.StatusForbidden
+		responses = []string{`{"kind":"Error","reason":"forbidden"}`}
+
+		machinePools, err := client.ListAll(context.Background(), client.NewMachinePoolClient(clusters), "123")
+		Expect(err).To(HaveOccurred())
+		Expect(machinePools).To(BeEmpty())
+	})
+
+	It("Keeps the paging metadata out of the list responses", func() {
+		var response client.OcmListResponse[v1.MachinePool] = itemsResponse[v1.MachinePool]{}
+		_, paged := response.(client.OcmPagedListResponse[v1.MachinePool])
+		Expect(paged).To(BeFalse())
+
+		response = client.NewPagedListResponse(http.StatusOK, []*v1.MachinePool{}, 1, 0, 0)
+		_, paged = response.(client.OcmPagedListResponse[v1.MachinePool])
+		Expect(paged).To(BeTrue())
+	})
+
+	It("Stops when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.ListAll(ctx, client.NewMachinePoolClient(clusters), "123")
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(requests).To(BeEmpty())
+	})
+})
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// itemsResponse is a list response without the paging metadata, like the ones implemented outside this module
+type itemsResponse[T any] struct {
+	items []*T
+}
+
+func (r itemsResponse[T]) Status() int {
+	return http.StatusOK
+}
+
+func (r itemsResponse[T]) Items() []*T {
+	return r.items
+}
+
+func (r itemsResponse[T]) HasItems() bool {
+	return len(r.items) != 0
+}
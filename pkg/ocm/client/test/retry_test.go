@@ -0,0 +1,229 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-online/ocm-common/pkg/ocm/client"
+)
+
+var _ = Describe("Retry", func() {
+	var ctrl *gomock.Controller
+	var machinePools *MockMachinePoolClient
+	var retrying client.MachinePoolClient
+	var policy client.RetryPolicy
+	ctx := context.Background()
+	networkError := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		machinePools = NewMockMachinePoolClient(ctrl)
+		policy = client.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, Multiplier: 2}
+		retrying = client.WithRetry[v1.MachinePool, string](machinePools, policy)
+	})
+
+	DescribeTable("Retries the transient errors", func(err error) {
+		machinePool := &v1.MachinePool{}
+		gomock.InOrder(
+			machinePools.EXPECT().Get(ctx, "123", "abc").Return(nil, err).Times(2),
+			machinePools.EXPECT().Get(ctx, "123", "abc").Return(machinePool, nil),
+		)
+
+		Expect(retrying.Get(ctx, "123", "abc")).To(BeIdenticalTo(machinePool))
+	},
+		Entry("Throttled", NewOcmError(http.StatusTooManyRequests, "throttled")),
+		Entry("Server error", NewOcmError(http.StatusServiceUnavailable, "unavailable")),
+		Entry("Network error", networkError),
+	)
+
+	DescribeTable("Doesn't retry the other errors", func(err error) {
+		machinePools.EXPECT().Delete(ctx, "123", "abc").Return(err)
+
+		Expect(retrying.Delete(ctx, "123", "abc")).To(MatchError(err))
+	},
+		Entry("Not found", NewOcmError(http.StatusNotFound, "not found")),
+		Entry("Bad request", NewOcmError(http.StatusBadRequest, "invalid")),
+		Entry("Canceled", context.Canceled),
+		Entry("Unknown", errors.New("unknown")),
+	)
+
+	It("Returns the last error when the attempts are exhausted", func() {
+		err := NewOcmError(http.StatusInternalServerError, "failure")
+		machinePools.EXPECT().List(ctx, "123", client.NewPaging(1, 10)).Return(nil, false, err).Times(3)
+
+		_, _, listErr := retrying.List(ctx, "123", client.NewPaging(1, 10))
+		Expect(listErr).To(BeIdenticalTo(err))
+	})
+
+	It("Doesn't retry Create on server and network errors", func() {
+		machinePools.EXPECT().Create(ctx, "123", gomock.Any()).Return(nil, networkError)
+		_, err := retrying.Create(ctx, "123", &v1.MachinePool{})
+		Expect(err).To(MatchError(networkError))
+
+		machinePools.EXPECT().Create(ctx, "123", gomock.Any()).Return(nil, NewOcmError(http.StatusBadGateway, "failure"))
+		_, err = retrying.Create(ctx, "123", &v1.MachinePool{})
+		Expect(client.IsServerError(err)).To(BeTrue())
+	})
+
+	It("Retries Create when throttled", func() {
+		gomock.InOrder(
+			machinePools.EXPECT().Create(ctx, "123", gomock.Any()).Return(nil, NewOcmError(http.StatusTooManyRequests, "throttled")),
+			machinePools.EXPECT().Create(ctx, "123", gomock.Any()).Return(&v1.MachinePool{}, nil),
+		)
+		_, err := retrying.Create(ctx, "123", &v1.MachinePool{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Retries Create on server errors when enabled", func() {
+		policy.RetryCreate = true
+		retrying = client.WithRetry[v1.MachinePool, string](machinePools, policy)
+		gomock.InOrder(
+			machinePools.EXPECT().Create(ctx, "123", gomock.Any()).Return(nil, NewOcmError(http.StatusBadGateway, "failure")),
+			machinePools.EXPECT().Create(ctx, "123", gomock.Any()).Return(&v1.MachinePool{}, nil),
+		)
+		_, err := retrying.Create(ctx, "123", &v1.MachinePool{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Retries the pages of ListAll", func() {
+		firstPage := make([]*v1.MachinePool, client.DefaultPageSize)
+		gomock.InOrder(
+			machinePools.EXPECT().List(ctx, "123", client.NewPaging(1, client.DefaultPageSize)).Return(firstPage, true, nil),
+			machinePools.EXPECT().List(ctx, "123", client.NewPaging(2, client.DefaultPageSize)).
+				Return(nil, false, NewOcmError(http.StatusServiceUnavailable, "unavailable")),
+			machinePools.EXPECT().List(ctx, "123", client.NewPaging(2, client.DefaultPageSize)).
+				Return([]*v1.MachinePool{{}}, true, nil),
+		)
+
+		Expect(client.ListAll(ctx, retrying, "123")).To(HaveLen(client.DefaultPageSize + 1))
+	})
+
+	It("Retries the pages of ListAll using the total returned by OCM", func() {
+		var requests []string
+		responses := []struct {
+			status int
+			body   string
+		}{
+			{http.StatusOK, `{"page":1,"size":2,"total":3,"items":[{"id":"a"},{"id":"b"}]}`},
+			{http.StatusTooManyRequests, `{"kind":"Error","id":"429","reason":"Too many requests"}`},
+			{http.StatusOK, `{"page":2,"size":1,"total":3,"items":[{"id":"c"}]}`},
+		}
+		clusters := v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			requests = append(requests, request.URL.RawQuery)
+			Expect(responses).NotTo(BeEmpty(), "unexpected request %s", request.URL)
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("Content-Type", "application/json")
+			recorder.WriteHeader(responses[0].status)
+			_, _ = recorder.WriteString(responses[0].body)
+			responses = responses[1:]
+			return recorder.Result(), nil
+		}), "/api/clusters_mgmt/v1/clusters")
+
+		machinePools, err := client.ListAll(ctx, client.WithRetry(client.NewMachinePoolClient(clusters), policy), "123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePools).To(HaveLen(3))
+		Expect(requests).To(Equal([]string{"page=1&size=100", "page=2&size=100", "page=2&size=100"}))
+	})
+
+	It("Treats a not found error on a retried Delete as a success", func() {
+		gomock.InOrder(
+			machinePools.EXPECT().Delete(ctx, "123", "abc").Return(NewOcmError(http.StatusServiceUnavailable, "unavailable")),
+			machinePools.EXPECT().Delete(ctx, "123", "abc").Return(NewOcmError(http.StatusNotFound, "not found")),
+		)
+
+		Expect(retrying.Delete(ctx, "123", "abc")).To(Succeed())
+	})
+
+	It("Stops waiting when the context is canceled", func() {
+		policy.InitialDelay = time.Hour
+		retrying = client.WithRetry[v1.MachinePool, string](machinePools, policy)
+		err := NewOcmError(http.StatusServiceUnavailable, "unavailable")
+		canceledCtx, cancel := context.WithCancel(ctx)
+		machinePools.EXPECT().Get(canceledCtx, "123", "abc").DoAndReturn(
+			func(context.Context, string, string) (*v1.MachinePool, error) {
+				time.AfterFunc(10*time.Millisecond, cancel)
+				return nil, err
+			})
+
+		_, getErr := retrying.Get(canceledCtx, "123", "abc")
+		Expect(getErr).To(MatchError(context.Canceled))
+		Expect(client.IsServerError(getErr)).To(BeTrue())
+	})
+
+	It("Doesn't wait beyond the deadline of the context", func() {
+		policy.InitialDelay = time.Hour
+		retrying = client.WithRetry[v1.MachinePool, string](machinePools, policy)
+		err := NewOcmError(http.StatusServiceUnavailable, "unavailable")
+		deadlineCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		machinePools.EXPECT().Get(deadlineCtx, "123", "abc").Return(nil, err)
+
+		_, getErr := retrying.Get(deadlineCtx, "123", "abc")
+		Expect(getErr).To(BeIdenticalTo(err))
+	})
+
+	It("Retries the single resource clients", func() {
+		kubeletConfigs := NewMockKubeletConfigClient(ctrl)
+		var retryingKubeletConfigs client.KubeletConfigClient = client.WithSingleRetry[v1.KubeletConfig](kubeletConfigs, policy)
+		gomock.InOrder(
+			kubeletConfigs.EXPECT().Exists(ctx, "123").Return(false, nil, networkError),
+			kubeletConfigs.EXPECT().Exists(ctx, "123").Return(true, &v1.KubeletConfig{}, nil),
+		)
+
+		exists, _, err := retryingKubeletConfigs.Exists(ctx, "123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
+	It("Waits for the delay requested by OCM", func() {
+		requests := 0
+		clusters := v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			requests++
+			recorder := httptest.NewRecorder()
+			recorder.Header().Set("Content-Type", "application/json")
+			if requests == 1 {
+				recorder.Header().Set("Retry-After", "1")
+				recorder.WriteHeader(http.StatusTooManyRequests)
+				_, _ = recorder.WriteString(`{"kind":"Error","id":"429","reason":"Too many requests"}`)
+			} else {
+				_, _ = recorder.WriteString(`{"id":"abc"}`)
+			}
+			return recorder.Result(), nil
+		}), "/api/clusters_mgmt/v1/clusters")
+
+		start := time.Now()
+		machinePool, err := client.WithRetry(client.NewMachinePoolClient(clusters), policy).Get(ctx, "123", "abc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePool.ID()).To(Equal("abc"))
+		Expect(requests).To(Equal(2))
+		Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+	})
+	It("Retries the throttled requests without a body", func() {
+		requests := 0
+		clusters := v1.NewClustersClient(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			requests++
+			recorder := httptest.NewRecorder()
+			if requests == 1 {
+				recorder.WriteHeader(http.StatusTooManyRequests)
+			} else {
+				recorder.Header().Set("Content-Type", "application/json")
+				_, _ = recorder.WriteString(`{"id":"abc"}`)
+			}
+			return recorder.Result(), nil
+		}), "/api/clusters_mgmt/v1/clusters")
+
+		machinePool, err := client.WithRetry(client.NewMachinePoolClient(clusters), policy).Get(ctx, "123", "abc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePool.ID()).To(Equal("abc"))
+		Expect(requests).To(Equal(2))
+	})
+})
@@ -41,3 +41,63 @@ func NewNodePool(modifyFn ...func(k *v1.NodePoolBuilder)) (*v1.NodePool, error)
 	}
 	return builder.Build()
 }
+
+// NewIdentityProvider creates an empty IdentityProvider that can be used in tests. Tests can
+// mutate the IdentityProvider to their requirements via the variadic list of functions
+func NewIdentityProvider(modifyFn ...func(k *v1.IdentityProviderBuilder)) (*v1.IdentityProvider, error) {
+	builder := &v1.IdentityProviderBuilder{}
+	for _, f := range modifyFn {
+		f(builder)
+	}
+	return builder.Build()
+}
+
+// NewIngress creates an empty Ingress that can be used in tests. Tests can
+// mutate the Ingress to their requirements via the variadic list of functions
+func NewIngress(modifyFn ...func(k *v1.IngressBuilder)) (*v1.Ingress, error) {
+	builder := &v1.IngressBuilder{}
+	for _, f := range modifyFn {
+		f(builder)
+	}
+	return builder.Build()
+}
+
+// NewUpgradePolicy creates an empty UpgradePolicy that can be used in tests. Tests can
+// mutate the UpgradePolicy to their requirements via the variadic list of functions
+func NewUpgradePolicy(modifyFn ...func(k *v1.UpgradePolicyBuilder)) (*v1.UpgradePolicy, error) {
+	builder := &v1.UpgradePolicyBuilder{}
+	for _, f := range modifyFn {
+		f(builder)
+	}
+	return builder.Build()
+}
+
+// NewNodePoolUpgradePolicy creates an empty NodePoolUpgradePolicy that can be used in tests. Tests can
+// mutate the NodePoolUpgradePolicy to their requirements via the variadic list of functions
+func NewNodePoolUpgradePolicy(modifyFn ...func(k *v1.NodePoolUpgradePolicyBuilder)) (*v1.NodePoolUpgradePolicy, error) {
+	builder := &v1.NodePoolUpgradePolicyBuilder{}
+	for _, f := range modifyFn {
+		f(builder)
+	}
+	return builder.Build()
+}
+
+// NewBreakGlassCredential creates an empty BreakGlassCredential that can be used in tests. Tests can
+// mutate the BreakGlassCredential to their requirements via the variadic list of functions
+func NewBreakGlassCredential(modifyFn ...func(k *v1.BreakGlassCredentialBuilder)) (*v1.BreakGlassCredential, error) {
+	builder := &v1.BreakGlassCredentialBuilder{}
+	for _, f := range modifyFn {
+		f(builder)
+	}
+	return builder.Build()
+}
+
+// NewExternalAuth creates an empty ExternalAuth that can be used in tests. Tests can
+// mutate the ExternalAuth to their requirements via the variadic list of functions
+func NewExternalAuth(modifyFn ...func(k *v1.ExternalAuthBuilder)) (*v1.ExternalAuth, error) {
+	builder := &v1.ExternalAuthBuilder{}
+	for _, f := range modifyFn {
+		f(builder)
+	}
+	return builder.Build()
+}
@@ -44,4 +44,58 @@ var _ = Describe("OCM Client Types", func() {
 		Expect(autoscaler.LogVerbosity()).To(Equal(10))
 	})
 
+	It("Allows customisation of IdentityProvider", func() {
+		identityProvider, err := NewIdentityProvider(func(k *v1.IdentityProviderBuilder) {
+			k.Challenge(true)
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identityProvider.Challenge()).To(BeTrue())
+	})
+
+	It("Allows customisation of Ingress", func() {
+		ingress, err := NewIngress(func(k *v1.IngressBuilder) {
+			k.Default(true)
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ingress.Default()).To(BeTrue())
+	})
+
+	It("Allows customisation of UpgradePolicy", func() {
+		upgradePolicy, err := NewUpgradePolicy(func(k *v1.UpgradePolicyBuilder) {
+			k.Version("4.15.1")
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upgradePolicy.Version()).To(Equal("4.15.1"))
+	})
+
+	It("Allows customisation of NodePoolUpgradePolicy", func() {
+		upgradePolicy, err := NewNodePoolUpgradePolicy(func(k *v1.NodePoolUpgradePolicyBuilder) {
+			k.NodePoolID("workers")
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upgradePolicy.NodePoolID()).To(Equal("workers"))
+	})
+
+	It("Allows customisation of BreakGlassCredential", func() {
+		credential, err := NewBreakGlassCredential(func(k *v1.BreakGlassCredentialBuilder) {
+			k.Username("admin")
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credential.Username()).To(Equal("admin"))
+	})
+
+	It("Allows customisation of ExternalAuth", func() {
+		externalAuth, err := NewExternalAuth(func(k *v1.ExternalAuthBuilder) {
+			k.ID("my-auth")
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(externalAuth.ID()).To(Equal("my-auth"))
+	})
+
 })
@@ -27,6 +27,9 @@ type CollectionClusterSubResource[T any, S any] interface {
 	List(ctx context.Context, clusterId string, paging Paging) ([]*T, bool, error)
 }
 
+// DefaultPageSize is the size of the pages requested by ListAll and All
+const DefaultPageSize = 100
+
 // Paging encapsulates paging requests for list methods
 type Paging struct {
 	size int
@@ -40,16 +43,41 @@ func NewPaging(page int, size int) Paging {
 	}
 }
 
+// Page returns the number (1 based) of the requested page
+func (p Paging) Page() int {
+	return p.page
+}
+
+// Size returns the number of items per page
+func (p Paging) Size() int {
+	return p.size
+}
+
 func NewListResponse[T any](status int, items []*T) OcmListResponse[T] {
 	return &DefaultListResponse[T]{
 		status: status,
 		items:  items,
+		size:   len(items),
+	}
+}
+
+// NewPagedListResponse creates a list response including the paging metadata returned by OCM
+func NewPagedListResponse[T any](status int, items []*T, page int, size int, total int) OcmPagedListResponse[T] {
+	return &DefaultListResponse[T]{
+		status: status,
+		items:  items,
+		page:   page,
+		size:   size,
+		total:  total,
 	}
 }
 
 type DefaultListResponse[T any] struct {
 	items  []*T
 	status int
+	page   int
+	size   int
+	total  int
 }
 
 func (d DefaultListResponse[T]) HasItems() bool {
@@ -64,6 +92,18 @@ func (d DefaultListResponse[T]) Items() []*T {
 	return d.items
 }
 
+func (d DefaultListResponse[T]) Page() int {
+	return d.page
+}
+
+func (d DefaultListResponse[T]) Size() int {
+	return d.size
+}
+
+func (d DefaultListResponse[T]) Total() int {
+	return d.total
+}
+
 type OcmResponse interface {
 	Status() int
 }
@@ -74,6 +114,18 @@ type OcmListResponse[T any] interface {
 	HasItems() bool
 }
 
+// OcmPagedListResponse is implemented by the list responses carrying the paging metadata returned by OCM (see
+// NewPagedListResponse). It is checked with a type assertion: the responses that don't implement it are paged by size
+type OcmPagedListResponse[T any] interface {
+	OcmListResponse[T]
+	// Page is the number (1 based) of the returned page
+	Page() int
+	// Size is the number of items in the returned page
+	Size() int
+	// Total is the number of items in all the pages. 0 if unknown
+	Total() int
+}
+
 type OcmInstanceResponse[T any] interface {
 	OcmResponse
 	Body() *T
@@ -98,7 +150,7 @@ type CollectionClusterSubResourceImpl[T any, S any] struct {
 
 func (c *CollectionClusterSubResourceImpl[T, S]) Get(ctx context.Context, clusterId string, instanceId S) (*T, error) {
 	response, err := c.getFunc(ctx, clusterId, instanceId)
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		return nil, err
 	}
 	return response.Body(), nil
@@ -110,7 +162,7 @@ func (c *CollectionClusterSubResourceImpl[T, S]) Exists(ctx context.Context, clu
 
 func (c *CollectionClusterSubResourceImpl[T, S]) Create(ctx context.Context, clusterId string, instance *T) (*T, error) {
 	response, err := c.createFunc(ctx, clusterId, instance)
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		return nil, err
 	}
 	return response.Body(), nil
@@ -118,31 +170,135 @@ func (c *CollectionClusterSubResourceImpl[T, S]) Create(ctx context.Context, clu
 
 func (c *CollectionClusterSubResourceImpl[T, S]) Update(ctx context.Context, clusterId string, instance *T) (*T, error) {
 	response, err := c.updateFunc(ctx, clusterId, instance)
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		return nil, err
 	}
 	return response.Body(), nil
 }
 
 func (c *CollectionClusterSubResourceImpl[T, S]) Delete(ctx context.Context, clusterId string, instanceId S) error {
-	_, err := c.deleteFunc(ctx, clusterId, instanceId)
-	return err
+	response, err := c.deleteFunc(ctx, clusterId, instanceId)
+	return WrapError(response, err)
 }
 
 func (c *CollectionClusterSubResourceImpl[T, S]) List(ctx context.Context, clusterId string, paging Paging) ([]*T, bool, error) {
-	response, err := c.listFunc(ctx, clusterId, paging)
+	response, err := c.listPage(ctx, clusterId, paging)
 	if err != nil {
 		return make([]*T, 0), false, err
 	}
 	return response.Items(), response.HasItems(), nil
 }
 
+// listPage returns the whole response of a page, including the paging metadata used by All
+func (c *CollectionClusterSubResourceImpl[T, S]) listPage(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[T], error) {
+	response, err := c.listFunc(ctx, clusterId, paging)
+	if err = WrapError(response, err); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// pageLister is implemented by the clients of this package, returning the paging metadata used by All
+type pageLister[T any] interface {
+	listPage(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[T], error)
+}
+
+// ListAll returns all the instances of the collection, requesting all the pages with List (see All)
+func ListAll[T any, S any](ctx context.Context, c CollectionClusterSubResource[T, S], clusterId string) ([]*T, error) {
+	return collect(All(ctx, c, clusterId))
+}
+
+// All returns an iterator over all the instances of the collection, requesting the pages with List while iterating.
+// The iterator has the signature of iter.Seq2[*T, error], that can't be used while this module targets Go 1.21: the
+// iteration stops after yielding an error. The clients of this package stop at the last page using the total returned
+// by OCM, for the others a page smaller than DefaultPageSize is the last one.
+func All[T any, S any](ctx context.Context, c CollectionClusterSubResource[T, S], clusterId string) func(yield func(*T, error) bool) {
+	return allPages(ctx, func(paging Paging) (OcmListResponse[T], error) {
+		return listPage(ctx, c, clusterId, paging)
+	})
+}
+
+// listPage requests a page, with its paging metadata when the client returns it
+func listPage[T any, S any](ctx context.Context, c CollectionClusterSubResource[T, S], clusterId string, paging Paging) (OcmListResponse[T], error) {
+	if lister, ok := c.(pageLister[T]); ok {
+		return lister.listPage(ctx, clusterId, paging)
+	}
+	items, _, err := c.List(ctx, clusterId, paging)
+	if err != nil {
+		return nil, err
+	}
+	return NewListResponse(http.StatusOK, items), nil
+}
+
+// collect returns all the items of an iterator like the ones returned by All, or the error stopping the iteration
+func collect[T any](all func(yield func(*T, error) bool)) ([]*T, error) {
+	items := make([]*T, 0)
+	var err error
+	all(func(item *T, itemErr error) bool {
+		if itemErr != nil {
+			err = itemErr
+			return false
+		}
+		items = append(items, item)
+		return true
+	})
+	if err != nil {
+		return make([]*T, 0), err
+	}
+	return items, nil
+}
+
+// allPages returns an iterator over the items of all the pages returned by listPage, stopping at the last page (see
+// isLastPage) or at the first error
+func allPages[T any](ctx context.Context, listPage func(paging Paging) (OcmListResponse[T], error)) func(yield func(*T, error) bool) {
+	return func(yield func(*T, error) bool) {
+		fetched := 0
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			response, err := listPage(NewPaging(page, DefaultPageSize))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range response.Items() {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			fetched += len(response.Items())
+			if isLastPage(response, fetched, DefaultPageSize) {
+				return
+			}
+		}
+	}
+}
+
+// isLastPage uses the total returned by OCM when available, so that no request is sent for an empty page. Otherwise,
+// a page smaller than the requested size is the last one.
+func isLastPage[T any](response OcmListResponse[T], fetched int, pageSize int) bool {
+	if len(response.Items()) == 0 {
+		return true
+	}
+	paged, ok := response.(OcmPagedListResponse[T])
+	if !ok {
+		return len(response.Items()) < pageSize
+	}
+	if paged.Total() > 0 {
+		return fetched >= paged.Total()
+	}
+	return paged.Size() < pageSize
+}
+
 var _ SingleClusterSubResource[interface{}] = &SingleClusterSubResourceImpl[interface{}]{}
 var _ CollectionClusterSubResource[interface{}, string] = &CollectionClusterSubResourceImpl[interface{}, string]{}
+var _ pageLister[interface{}] = &CollectionClusterSubResourceImpl[interface{}, string]{}
 
 func (s *SingleClusterSubResourceImpl[T]) Get(ctx context.Context, clusterId string) (*T, error) {
 	response, err := s.getFunc(ctx, clusterId)
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		return nil, err
 	}
 
@@ -150,9 +306,9 @@ func (s *SingleClusterSubResourceImpl[T]) Get(ctx context.Context, clusterId str
 }
 
 func exists[T any](response OcmInstanceResponse[T], err error) (bool, *T, error) {
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		// A 404 indicates that the resource does not exist
-		if response.Status() == http.StatusNotFound {
+		if IsNotFound(err) {
 			return false, nil, nil
 		}
 		return false, nil, err
@@ -166,7 +322,7 @@ func (s *SingleClusterSubResourceImpl[T]) Exists(ctx context.Context, clusterId
 
 func (s *SingleClusterSubResourceImpl[T]) Create(ctx context.Context, clusterId string, instance *T) (*T, error) {
 	response, err := s.createFunc(ctx, clusterId, instance)
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		return nil, err
 	}
 	return response.Body(), nil
@@ -174,7 +330,7 @@ func (s *SingleClusterSubResourceImpl[T]) Create(ctx context.Context, clusterId
 
 func (s *SingleClusterSubResourceImpl[T]) Update(ctx context.Context, clusterId string, instance *T) (*T, error) {
 	response, err := s.updateFunc(ctx, clusterId, instance)
-	if err != nil {
+	if err = WrapError(response, err); err != nil {
 		return nil, err
 	}
 
@@ -182,6 +338,6 @@ func (s *SingleClusterSubResourceImpl[T]) Update(ctx context.Context, clusterId
 }
 
 func (s *SingleClusterSubResourceImpl[T]) Delete(ctx context.Context, clusterId string) error {
-	_, err := s.deleteFunc(ctx, clusterId)
-	return err
+	response, err := s.deleteFunc(ctx, clusterId)
+	return WrapError(response, err)
 }
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+//go:generate mockgen -source=upgradepolicy_client.go -package=test -destination=test/mock_upgradepolicy_client.go
+type UpgradePolicyClient interface {
+	CollectionClusterSubResource[v1.UpgradePolicy, string]
+}
+
+func NewUpgradePolicyClient(collection *v1.ClustersClient) UpgradePolicyClient {
+	return &CollectionClusterSubResourceImpl[v1.UpgradePolicy, string]{
+		getFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmInstanceResponse[v1.UpgradePolicy], error) {
+			return collection.Cluster(clusterId).UpgradePolicies().UpgradePolicy(instanceId).Get().SendContext(ctx)
+		},
+		updateFunc: func(ctx context.Context, clusterId string, instance *v1.UpgradePolicy) (OcmInstanceResponse[v1.UpgradePolicy], error) {
+			return collection.Cluster(clusterId).UpgradePolicies().UpgradePolicy(instance.ID()).Update().Body(instance).SendContext(ctx)
+		},
+		createFunc: func(ctx context.Context, clusterId string, instance *v1.UpgradePolicy) (OcmInstanceResponse[v1.UpgradePolicy], error) {
+			return collection.Cluster(clusterId).UpgradePolicies().Add().Body(instance).SendContext(ctx)
+		},
+		deleteFunc: func(ctx context.Context, clusterId string, instanceId string) (OcmResponse, error) {
+			return collection.Cluster(clusterId).UpgradePolicies().UpgradePolicy(instanceId).Delete().SendContext(ctx)
+		},
+		listFunc: func(ctx context.Context, clusterId string, paging Paging) (OcmListResponse[v1.UpgradePolicy], error) {
+			resp, err := collection.Cluster(clusterId).UpgradePolicies().List().Size(paging.size).Page(paging.page).SendContext(ctx)
+			if err = WrapError(resp, err); err != nil {
+				return nil, err
+			}
+			return NewPagedListResponse(resp.Status(), resp.Items().Slice(), resp.Page(), resp.Size(), resp.Total()), nil
+		},
+	}
+}
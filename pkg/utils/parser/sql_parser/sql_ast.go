@@ -0,0 +1,165 @@
+package sql_parser
+
+// Node - a node of the tree returned by SQLParser.ParseToAST.
+// The concrete types are: *LogicalNode, *GroupNode, *ComparisonNode, *InNode, *IsNullNode, *BetweenNode, *ColumnNode,
+// *JSONBPathNode, *FunctionNode and *ValueNode
+type Node interface {
+	node()
+}
+
+type LogicalOperator string
+
+const (
+	LogicalAnd LogicalOperator = "AND"
+	LogicalOr  LogicalOperator = "OR"
+)
+
+type ComparisonOperator string
+
+const (
+	OpEq       ComparisonOperator = "="
+	OpNotEq    ComparisonOperator = "<>"
+	OpGt       ComparisonOperator = ">"
+	OpLt       ComparisonOperator = "<"
+	OpGte      ComparisonOperator = ">="
+	OpLte      ComparisonOperator = "<="
+	OpLike     ComparisonOperator = "LIKE"
+	OpILike    ComparisonOperator = "ILIKE"
+	OpContains ComparisonOperator = "@>"
+)
+
+// LogicalNode - joins two expressions with AND or OR
+type LogicalNode struct {
+	Operator LogicalOperator
+	// Keyword - the operator as typed by the user (ie: `and`). Used only when rendering the query.
+	Keyword string
+	Left    Node
+	Right   Node
+}
+
+// GroupNode - an expression surrounded by braces
+type GroupNode struct {
+	Expr Node
+}
+
+// ComparisonNode - compares the Left operand (a *ColumnNode, a *JSONBPathNode or a *FunctionNode) with a value
+type ComparisonNode struct {
+	Left     Node
+	Operator ComparisonOperator
+	// Keyword - the operator as typed by the user (ie: `like`). Used only when rendering the query.
+	Keyword string
+	Value   *ValueNode
+}
+
+// InNode - checks if the Left operand (a *ColumnNode, a *JSONBPathNode or a *FunctionNode) is (or is not) in the list of values
+type InNode struct {
+	Left    Node
+	Negated bool
+	Values  []*ValueNode
+	// NotKeyword and InKeyword - the keywords as typed by the user. Used only when rendering the query.
+	NotKeyword string
+	InKeyword  string
+}
+
+// IsNullNode - checks if the Left operand (a *ColumnNode, a *JSONBPathNode or a *FunctionNode) IS [NOT] NULL
+type IsNullNode struct {
+	Left    Node
+	Negated bool
+	// IsKeyword, NotKeyword and NullKeyword - the keywords as typed by the user. Used only when rendering the query.
+	IsKeyword   string
+	NotKeyword  string
+	NullKeyword string
+}
+
+// BetweenNode - checks if the Left operand (a *ColumnNode, a *JSONBPathNode or a *FunctionNode) is [NOT] BETWEEN Lower AND Upper
+type BetweenNode struct {
+	Left    Node
+	Negated bool
+	Lower   *ValueNode
+	Upper   *ValueNode
+	// NotKeyword, BetweenKeyword and AndKeyword - the keywords as typed by the user. Used only when rendering the query.
+	NotKeyword     string
+	BetweenKeyword string
+	AndKeyword     string
+}
+
+// ColumnNode - a column name. The name is always lowercase and never contains the column prefix.
+type ColumnNode struct {
+	Name string
+}
+
+// JSONBPathNode - a JSONB path, ie: `manifest -> 'data' ->> 'name'`.
+// Keys contains the unquoted keys in the order they appear. If AsText is true, the last key is extracted with `->>`
+type JSONBPathNode struct {
+	Column *ColumnNode
+	Keys   []string
+	AsText bool
+}
+
+// FunctionNode - a function call, ie: `lower(name)`. Args can be *ColumnNode or *ValueNode. The name is always lowercase.
+type FunctionNode struct {
+	Name string
+	Args []Node
+}
+
+// ValueNode - a value that will be passed to the database as a placeholder
+type ValueNode struct {
+	Value interface{}
+	// Quoted - true if the value was quoted in the parsed string
+	Quoted bool
+}
+
+func (*LogicalNode) node()    {}
+func (*GroupNode) node()      {}
+func (*ComparisonNode) node() {}
+func (*InNode) node()         {}
+func (*IsNullNode) node()     {}
+func (*BetweenNode) node()    {}
+func (*ColumnNode) node()     {}
+func (*JSONBPathNode) node()  {}
+func (*FunctionNode) node()   {}
+func (*ValueNode) node()      {}
+
+// Walk - traverses the tree in depth-first order calling `visit` for each node.
+// If `visit` returns false, the children of the current node are not visited.
+func Walk(node Node, visit func(node Node) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *LogicalNode:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	case *GroupNode:
+		Walk(n.Expr, visit)
+	case *ComparisonNode:
+		Walk(n.Left, visit)
+		if n.Value != nil {
+			Walk(n.Value, visit)
+		}
+	case *InNode:
+		Walk(n.Left, visit)
+		for _, v := range n.Values {
+			Walk(v, visit)
+		}
+	case *IsNullNode:
+		Walk(n.Left, visit)
+	case *BetweenNode:
+		Walk(n.Left, visit)
+		if n.Lower != nil {
+			Walk(n.Lower, visit)
+		}
+		if n.Upper != nil {
+			Walk(n.Upper, visit)
+		}
+	case *JSONBPathNode:
+		if n.Column != nil {
+			Walk(n.Column, visit)
+		}
+	case *FunctionNode:
+		for _, arg := range n.Args {
+			Walk(arg, visit)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package sql_parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// astFrame - the operands and the logical operators found inside a single brace level
+type astFrame struct {
+	operands  []Node
+	operators []*LogicalNode
+}
+
+// reduce builds the tree for the frame honouring the precedence of AND over OR
+func (f *astFrame) reduce() (Node, error) {
+	if len(f.operands) == 0 || len(f.operands) != len(f.operators)+1 {
+		return nil, fmt.Errorf("incomplete expression")
+	}
+
+	// fold all the ANDs first
+	terms := []Node{f.operands[0]}
+	var orOperators []*LogicalNode
+	for i, op := range f.operators {
+		if op.Operator == LogicalAnd {
+			op.Left = terms[len(terms)-1]
+			op.Right = f.operands[i+1]
+			terms[len(terms)-1] = op
+		} else {
+			orOperators = append(orOperators, op)
+			terms = append(terms, f.operands[i+1])
+		}
+	}
+
+	// and then the ORs
+	ret := terms[0]
+	for i, op := range orOperators {
+		op.Left = ret
+		op.Right = terms[i+1]
+		ret = op
+	}
+	return ret, nil
+}
+
+// astBuilder - builds the AST one token at a time. It is fed by the transition interceptor.
+type astBuilder struct {
+	frames    []*astFrame
+	types     columnTypeRegistry
+	functions map[string]SQLFunction
+
+	// the left operand of the predicate currently being parsed
+	left Node
+	// the function call currently being parsed
+	function *FunctionNode
+	// the comparison currently being parsed
+	comparison *ComparisonNode
+	// the IN list currently being parsed
+	inList *InNode
+	// the IS [NOT] NULL currently being parsed
+	isNull *IsNullNode
+	// the BETWEEN currently being parsed
+	between *BetweenNode
+	// the NOT keyword preceding an IN or a BETWEEN
+	notKeyword string
+}
+
+func newASTBuilder(types columnTypeRegistry, functions map[string]SQLFunction) *astBuilder {
+	return &astBuilder{frames: []*astFrame{{}}, types: types, functions: functions}
+}
+
+func (b *astBuilder) currentFrame() *astFrame {
+	return b.frames[len(b.frames)-1]
+}
+
+func (b *astBuilder) push(node Node) {
+	frame := b.currentFrame()
+	frame.operands = append(frame.operands, node)
+	b.left = nil
+	b.function = nil
+	b.comparison = nil
+	b.inList = nil
+	b.isNull = nil
+	b.between = nil
+	b.notKeyword = ""
+}
+
+// onToken - updates the tree with the received token. `tokenName` is the name of the state the machine moved to.
+func (b *astBuilder) onToken(tokenName string, tokenValue string) error {
+	switch tokenName {
+	case openBrace:
+		b.frames = append(b.frames, &astFrame{})
+	case closedBrace:
+		if b.inList != nil {
+			b.push(b.inList)
+			return nil
+		}
+		if len(b.frames) < 2 {
+			return fmt.Errorf("unexpected ')'")
+		}
+		expr, err := b.currentFrame().reduce()
+		if err != nil {
+			return err
+		}
+		b.frames = b.frames[:len(b.frames)-1]
+		b.push(&GroupNode{Expr: expr})
+	case and, or:
+		frame := b.currentFrame()
+		frame.operators = append(frame.operators, &LogicalNode{
+			Operator: LogicalOperator(strings.ToUpper(tokenValue)),
+			Keyword:  tokenValue,
+		})
+	case column:
+		b.left = &ColumnNode{Name: strings.ToLower(tokenValue)}
+	case function:
+		b.function = &FunctionNode{Name: strings.ToLower(tokenValue)}
+	case functionArgColumn:
+		b.function.Args = append(b.function.Args, &ColumnNode{Name: strings.ToLower(tokenValue)})
+	case functionArgValue, functionArgQuoted:
+		b.function.Args = append(b.function.Args, newValueNode(tokenName == functionArgQuoted, tokenValue))
+	case functionClosedBrace:
+		if err := b.functions[b.function.Name].checkArgs(len(b.function.Args)); err != nil {
+			return err
+		}
+		b.left = b.function
+	case jsonbArrow, jsonbToString:
+		if col, ok := b.left.(*ColumnNode); ok {
+			if err := b.types.checkOperator(col, tokenValue); err != nil {
+				return err
+			}
+			b.left = &JSONBPathNode{Column: col}
+		}
+		if tokenName == jsonbToString {
+			b.left.(*JSONBPathNode).AsText = true
+		}
+	case jsonbField, jsonbFieldToStringify:
+		path := b.left.(*JSONBPathNode)
+		path.Keys = append(path.Keys, unquote(tokenValue))
+		if err := b.types.checkJSONBPath(path, false); err != nil {
+			return err
+		}
+	case eq, notEq, gt, lt, gte, lte, like, ilike, jsonbContains:
+		if err := b.checkOperator(tokenValue); err != nil {
+			return err
+		}
+		b.comparison = &ComparisonNode{
+			Left:     b.left,
+			Operator: ComparisonOperator(strings.ToUpper(tokenValue)),
+			Keyword:  tokenValue,
+		}
+	case value, quotedValue:
+		v, err := b.newValueNode(tokenName == quotedValue, tokenValue)
+		if err != nil {
+			return err
+		}
+		if b.comparison.Operator == OpContains {
+			if err := checkJSONValue(tokenValue, v); err != nil {
+				return err
+			}
+		}
+		b.comparison.Value = v
+		b.push(b.comparison)
+	case not:
+		b.notKeyword = tokenValue
+	case in:
+		if err := b.checkOperator(tokenValue); err != nil {
+			return err
+		}
+		b.inList = &InNode{
+			Left:       b.left,
+			Negated:    b.notKeyword != "",
+			NotKeyword: b.notKeyword,
+			InKeyword:  tokenValue,
+		}
+	case valueInList, quotedValueInList:
+		v, err := b.newValueNode(tokenName == quotedValueInList, tokenValue)
+		if err != nil {
+			return err
+		}
+		b.inList.Values = append(b.inList.Values, v)
+	case is:
+		if err := b.checkOperator(tokenValue); err != nil {
+			return err
+		}
+		b.isNull = &IsNullNode{Left: b.left, IsKeyword: tokenValue}
+	case isNot:
+		b.isNull.Negated = true
+		b.isNull.NotKeyword = tokenValue
+	case null:
+		b.isNull.NullKeyword = tokenValue
+		b.push(b.isNull)
+	case between:
+		if err := b.checkOperator(tokenValue); err != nil {
+			return err
+		}
+		b.between = &BetweenNode{
+			Left:           b.left,
+			Negated:        b.notKeyword != "",
+			NotKeyword:     b.notKeyword,
+			BetweenKeyword: tokenValue,
+		}
+	case betweenLowerValue, betweenLowerQuoted:
+		v, err := b.newValueNode(tokenName == betweenLowerQuoted, tokenValue)
+		if err != nil {
+			return err
+		}
+		b.between.Lower = v
+	case betweenAnd:
+		b.between.AndKeyword = tokenValue
+	case betweenUpperValue, betweenUpperQuoted:
+		v, err := b.newValueNode(tokenName == betweenUpperQuoted, tokenValue)
+		if err != nil {
+			return err
+		}
+		b.between.Upper = v
+		b.push(b.between)
+	}
+	return nil
+}
+
+// checkOperator validates the current left operand, now complete, and the operator applied to it
+func (b *astBuilder) checkOperator(operator string) error {
+	if err := b.types.checkJSONBPath(b.left, true); err != nil {
+		return err
+	}
+	return b.types.checkOperator(b.left, operator)
+}
+
+// build returns the root of the tree. To be called when the whole string has been parsed.
+func (b *astBuilder) build() (Node, error) {
+	if len(b.frames) != 1 {
+		return nil, fmt.Errorf("EOF while searching for closing brace ')'")
+	}
+	return b.currentFrame().reduce()
+}
+
+// newValueNode creates the node for the received value, validating and coercing it to the type of the current column
+func (b *astBuilder) newValueNode(quoted bool, tokenValue string) (*ValueNode, error) {
+	v := newValueNode(quoted, tokenValue)
+	if err := b.types.coerce(b.left, tokenValue, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func newValueNode(quoted bool, tokenValue string) *ValueNode {
+	if quoted {
+		return &ValueNode{Value: unquote(tokenValue), Quoted: true}
+	}
+	return &ValueNode{Value: tokenValue}
+}
+
+// unquote removes the surrounding quotes and unescapes the escaped quotes
+func unquote(tokenValue string) string {
+	tmp := strings.ReplaceAll(tokenValue, `\'`, "'")
+	if len(tmp) > 1 {
+		tmp = string([]rune(tmp)[1 : len([]rune(tmp))-1])
+	}
+	return tmp
+}
+
+// quote is the reverse of unquote: it quotes the value as expected by the SQL scanner. The rendered SQL must use
+// sqlStringLiteral instead
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
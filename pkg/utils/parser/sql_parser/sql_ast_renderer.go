@@ -0,0 +1,198 @@
+package sql_parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlaceholderStyle - the style of the placeholders in the rendered query
+type PlaceholderStyle int
+
+const (
+	// QuestionMarkPlaceholder - `?` placeholders (ie: GORM). This is the default.
+	QuestionMarkPlaceholder PlaceholderStyle = iota
+	// DollarPlaceholder - numbered `$1`, `$2`, ... placeholders (ie: pgx or database/sql with PostgreSQL)
+	DollarPlaceholder
+	// NamedPlaceholder - named `:p1`, `:p2`, ... placeholders (ie: sqlx). Values are returned as a map[string]interface{}
+	NamedPlaceholder
+)
+
+// astRenderer - renders an AST to a query string with placeholders and the list of values
+type astRenderer struct {
+	columnPrefix     string
+	columnMapping    map[string]string
+	placeholderStyle PlaceholderStyle
+
+	qry    strings.Builder
+	values []interface{}
+}
+
+func (r *astRenderer) render(node Node) error {
+	switch n := node.(type) {
+	case *LogicalNode:
+		if err := r.renderOperand(n.Left, n.Operator); err != nil {
+			return err
+		}
+		r.qry.WriteString(" " + keyword(n.Keyword, string(n.Operator)) + " ")
+		return r.renderOperand(n.Right, n.Operator)
+	case *GroupNode:
+		r.qry.WriteString("(")
+		if err := r.render(n.Expr); err != nil {
+			return err
+		}
+		r.qry.WriteString(")")
+	case *ComparisonNode:
+		if err := r.render(n.Left); err != nil {
+			return err
+		}
+		r.qry.WriteString(" " + keyword(n.Keyword, string(n.Operator)) + " ")
+		if n.Value == nil {
+			return fmt.Errorf("missing value for operator '%s'", n.Operator)
+		}
+		return r.render(n.Value)
+	case *InNode:
+		if err := r.render(n.Left); err != nil {
+			return err
+		}
+		if n.Negated {
+			r.qry.WriteString(" " + keyword(n.NotKeyword, "NOT") + " ")
+		}
+		r.qry.WriteString(" " + keyword(n.InKeyword, "IN") + "(")
+		if len(n.Values) == 0 {
+			return fmt.Errorf("empty IN list")
+		}
+		for i, v := range n.Values {
+			if i > 0 {
+				r.qry.WriteString(" ,")
+			}
+			r.qry.WriteString(" ")
+			if err := r.render(v); err != nil {
+				return err
+			}
+		}
+		r.qry.WriteString(")")
+	case *IsNullNode:
+		if err := r.render(n.Left); err != nil {
+			return err
+		}
+		r.qry.WriteString(" " + keyword(n.IsKeyword, "IS"))
+		if n.Negated {
+			r.qry.WriteString(" " + keyword(n.NotKeyword, "NOT"))
+		}
+		r.qry.WriteString(" " + keyword(n.NullKeyword, "NULL"))
+	case *BetweenNode:
+		if err := r.render(n.Left); err != nil {
+			return err
+		}
+		if n.Negated {
+			r.qry.WriteString(" " + keyword(n.NotKeyword, "NOT"))
+		}
+		r.qry.WriteString(" " + keyword(n.BetweenKeyword, "BETWEEN") + " ")
+		if n.Lower == nil || n.Upper == nil {
+			return fmt.Errorf("missing value for operator 'BETWEEN'")
+		}
+		if err := r.render(n.Lower); err != nil {
+			return err
+		}
+		r.qry.WriteString(" " + keyword(n.AndKeyword, "AND") + " ")
+		if err := r.render(n.Upper); err != nil {
+			return err
+		}
+	case *ColumnNode:
+		if expression, ok := r.columnMapping[n.Name]; ok {
+			// mapped columns are rendered as they are: the prefix is not added
+			r.qry.WriteString(expression)
+			return nil
+		}
+		columnName := n.Name
+		if r.columnPrefix != "" && !strings.HasPrefix(columnName, r.columnPrefix+".") {
+			columnName = r.columnPrefix + "." + columnName
+		}
+		r.qry.WriteString(columnName)
+	case *JSONBPathNode:
+		if err := r.render(n.Column); err != nil {
+			return err
+		}
+		for i, key := range n.Keys {
+			if n.AsText && i == len(n.Keys)-1 {
+				r.qry.WriteString(" ->> ")
+			} else {
+				r.qry.WriteString(" -> ")
+			}
+			r.qry.WriteString(sqlStringLiteral(key))
+		}
+	case *FunctionNode:
+		r.qry.WriteString(n.Name + "(")
+		for i, arg := range n.Args {
+			if i > 0 {
+				r.qry.WriteString(", ")
+			}
+			switch arg.(type) {
+			case *ColumnNode, *ValueNode:
+				if err := r.render(arg); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported argument type %T for function '%s'", arg, n.Name)
+			}
+		}
+		r.qry.WriteString(")")
+	case *ValueNode:
+		r.values = append(r.values, n.Value)
+		r.qry.WriteString(r.placeholder(len(r.values)))
+	default:
+		return fmt.Errorf("unsupported node type %T", node)
+	}
+	return nil
+}
+
+// placeholder returns the placeholder for the n-th (1 based) value
+func (r *astRenderer) placeholder(n int) string {
+	switch r.placeholderStyle {
+	case DollarPlaceholder:
+		return fmt.Sprintf("$%d", n)
+	case NamedPlaceholder:
+		return ":" + placeholderName(n)
+	default:
+		return "?"
+	}
+}
+
+// result returns the rendered values in the format required by the placeholder style
+func (r *astRenderer) result() interface{} {
+	if r.placeholderStyle != NamedPlaceholder {
+		return r.values
+	}
+
+	ret := make(map[string]interface{}, len(r.values))
+	for i, v := range r.values {
+		ret[placeholderName(i+1)] = v
+	}
+	return ret
+}
+
+func placeholderName(n int) string {
+	return fmt.Sprintf("p%d", n)
+}
+
+// renderOperand renders the operand of a logical operator adding braces when they are needed to preserve the precedence
+func (r *astRenderer) renderOperand(node Node, parentOperator LogicalOperator) error {
+	if child, ok := node.(*LogicalNode); ok && parentOperator == LogicalAnd && child.Operator == LogicalOr {
+		return r.render(&GroupNode{Expr: child})
+	}
+	return r.render(node)
+}
+
+// keyword returns the keyword as typed by the user if it matches the operator, the operator otherwise
+func keyword(typed string, operator string) string {
+	if strings.EqualFold(typed, operator) {
+		return typed
+	}
+	return operator
+}
+
+// sqlStringLiteral renders the value as a SQL string literal, doubling the quotes as required by the SQL standard. The
+// backslashes are not escape characters in standard strings (PostgreSQL `standard_conforming_strings`, on by default).
+func sqlStringLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
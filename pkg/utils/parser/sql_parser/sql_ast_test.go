@@ -0,0 +1,103 @@
+package sql_parser
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SQLParser AST", func() {
+	col := func(name string) *ColumnNode {
+		return &ColumnNode{Name: name}
+	}
+	val := func(v string, quoted bool) *ValueNode {
+		return &ValueNode{Value: v, Quoted: quoted}
+	}
+
+	DescribeTable("ParseToAST", func(qry string, expected Node) {
+		tree, err := NewSQLParser().ParseToAST(qry)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tree).To(Equal(expected))
+	},
+		Entry("simple comparison", "NAME = 'mickey'",
+			&ComparisonNode{Left: col("name"), Operator: OpEq, Keyword: "=", Value: val("mickey", true)},
+		),
+		Entry("AND has precedence over OR", "a = 1 or b = 2 and c like '%x%'",
+			&LogicalNode{
+				Operator: LogicalOr, Keyword: "or",
+				Left: &ComparisonNode{Left: col("a"), Operator: OpEq, Keyword: "=", Value: val("1", false)},
+				Right: &LogicalNode{
+					Operator: LogicalAnd, Keyword: "and",
+					Left:  &ComparisonNode{Left: col("b"), Operator: OpEq, Keyword: "=", Value: val("2", false)},
+					Right: &ComparisonNode{Left: col("c"), Operator: OpLike, Keyword: "like", Value: val("%x%", true)},
+				},
+			},
+		),
+		Entry("braces", "(a = 1 or b = 2) AND c <> 3",
+			&LogicalNode{
+				Operator: LogicalAnd, Keyword: "AND",
+				Left: &GroupNode{Expr: &LogicalNode{
+					Operator: LogicalOr, Keyword: "or",
+					Left:  &ComparisonNode{Left: col("a"), Operator: OpEq, Keyword: "=", Value: val("1", false)},
+					Right: &ComparisonNode{Left: col("b"), Operator: OpEq, Keyword: "=", Value: val("2", false)},
+				}},
+				Right: &ComparisonNode{Left: col("c"), Operator: OpNotEq, Keyword: "<>", Value: val("3", false)},
+			},
+		),
+		Entry("NOT IN list", "owner not in (owner1, 'owner2')",
+			&InNode{
+				Left: col("owner"), Negated: true, NotKeyword: "not", InKeyword: "in",
+				Values: []*ValueNode{val("owner1", false), val("owner2", true)},
+			},
+		),
+		Entry("JSONB path", `manifest->'data'->>'foo' ilike 'bar'`,
+			&ComparisonNode{
+				Left:     &JSONBPathNode{Column: col("manifest"), Keys: []string{"data", "foo"}, AsText: true},
+				Operator: OpILike, Keyword: "ilike",
+				Value: val("bar", true),
+			},
+		),
+		Entry("JSONB contains", `payload -> 'data' @> '{"a":1}'`,
+			&ComparisonNode{
+				Left:     &JSONBPathNode{Column: col("payload"), Keys: []string{"data"}},
+				Operator: OpContains, Keyword: "@>",
+				Value: val(`{"a":1}`, true),
+			},
+		),
+	)
+
+	It("Walk visits every column", func() {
+		tree, err := NewSQLParser().ParseToAST(`(a = 1 or b in (1, 2)) and c->'x'->>'y' = 'z'`)
+		Expect(err).ToNot(HaveOccurred())
+		var columns []string
+		Walk(tree, func(node Node) bool {
+			if c, ok := node.(*ColumnNode); ok {
+				columns = append(columns, c.Name)
+			}
+			return true
+		})
+		Expect(columns).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("Renders a rewritten tree", func() {
+		parser := NewSQLParser(WithColumnPrefix("main"))
+		tree, err := parser.ParseToAST("name = 'mickey' or name = 'minnie'")
+		Expect(err).ToNot(HaveOccurred())
+
+		// restrict the query to a single tenant
+		tree = &LogicalNode{
+			Operator: LogicalAnd,
+			Left:     tree,
+			Right:    &ComparisonNode{Left: &ColumnNode{Name: "tenant"}, Operator: OpEq, Value: &ValueNode{Value: "acme"}},
+		}
+		qry, values, err := parser.Render(tree)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(qry).To(Equal("(main.name = ? or main.name = ?) AND main.tenant = ?"))
+		Expect(values).To(Equal([]interface{}{"mickey", "minnie", "acme"}))
+	})
+
+	It("Fails rendering an invalid tree", func() {
+		_, _, err := NewSQLParser().Render(&InNode{Left: &ColumnNode{Name: "a"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("empty IN list"))
+	})
+})
@@ -0,0 +1,149 @@
+package sql_parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ColumnKind string
+
+const (
+	ColumnKindString    ColumnKind = "string"
+	ColumnKindInt       ColumnKind = "int"
+	ColumnKindBool      ColumnKind = "bool"
+	ColumnKindTimestamp ColumnKind = "timestamp"
+	ColumnKindEnum      ColumnKind = "enum"
+	ColumnKindJSONB     ColumnKind = "jsonb"
+)
+
+// ColumnType - the type of column. Used to validate and coerce the values compared with the column
+type ColumnType struct {
+	Kind ColumnKind
+	// EnumValues - the accepted values of a ColumnKindEnum column
+	EnumValues []string
+	// JSONB - the schema of a ColumnKindJSONB column. When nil, any path is allowed.
+	JSONB *JSONBSchema
+}
+
+func StringColumn() ColumnType {
+	return ColumnType{Kind: ColumnKindString}
+}
+
+func IntColumn() ColumnType {
+	return ColumnType{Kind: ColumnKindInt}
+}
+
+func BoolColumn() ColumnType {
+	return ColumnType{Kind: ColumnKindBool}
+}
+
+func TimestampColumn() ColumnType {
+	return ColumnType{Kind: ColumnKindTimestamp}
+}
+
+func EnumColumn(values ...string) ColumnType {
+	return ColumnType{Kind: ColumnKindEnum, EnumValues: values}
+}
+
+// JSONBColumn - a JSONB column. By default any path can be used, use the options to restrict them.
+func JSONBColumn(options ...JSONBOption) ColumnType {
+	columnType := ColumnType{Kind: ColumnKindJSONB}
+	if len(options) > 0 {
+		columnType.JSONB = &JSONBSchema{}
+		for _, option := range options {
+			option(columnType.JSONB)
+		}
+	}
+	return columnType
+}
+
+// timestampLayouts - the accepted formats for ColumnKindTimestamp values
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// supportedOperators - the operators that can be used with each kind of column.
+// `IS NULL` is supported by every kind of column
+var supportedOperators = map[ColumnKind][]string{
+	ColumnKindString:    {"=", "<>", "<", ">", "<=", ">=", "LIKE", "ILIKE", "IN", "BETWEEN"},
+	ColumnKindInt:       {"=", "<>", "<", ">", "<=", ">=", "IN", "BETWEEN"},
+	ColumnKindBool:      {"=", "<>", "IN"},
+	ColumnKindTimestamp: {"=", "<>", "<", ">", "<=", ">=", "IN", "BETWEEN"},
+	ColumnKindEnum:      {"=", "<>", "IN"},
+	ColumnKindJSONB:     {"->"},
+}
+
+// columnTypeRegistry - the type of each column, indexed by lowercase column name
+type columnTypeRegistry map[string]ColumnType
+
+// lookup returns the type of the operand. Values compared with `->>` are always strings.
+// Returns false if the operand is not typed.
+func (r columnTypeRegistry) lookup(operand Node) (string, ColumnType, bool) {
+	switch o := operand.(type) {
+	case *ColumnNode:
+		t, ok := r[o.Name]
+		return o.Name, t, ok
+	case *JSONBPathNode:
+		if o.AsText {
+			return o.Column.Name, StringColumn(), true
+		}
+	}
+	return "", ColumnType{}, false
+}
+
+// checkOperator returns an error if the operator can't be used with the operand
+func (r columnTypeRegistry) checkOperator(operand Node, operator string) error {
+	operator = strings.ToUpper(operator)
+	if operator == "IS" {
+		return nil
+	}
+	name, columnType, ok := r.lookup(operand)
+	if !ok {
+		return nil
+	}
+	if !contains(supportedOperators[columnType.Kind], operator) {
+		return fmt.Errorf("operator '%s' is not supported for column '%s' of type %s", operator, name, columnType.Kind)
+	}
+	return nil
+}
+
+// coerce validates the value and converts it to the Go type matching the operand
+func (r columnTypeRegistry) coerce(operand Node, tokenValue string, v *ValueNode) error {
+	name, columnType, ok := r.lookup(operand)
+	if !ok {
+		return nil
+	}
+
+	s, _ := v.Value.(string)
+	invalidValue := func() error {
+		return fmt.Errorf("invalid value %s for column '%s' of type %s", tokenValue, name, columnType.Kind)
+	}
+
+	switch columnType.Kind {
+	case ColumnKindInt:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return invalidValue()
+		}
+		v.Value = i
+	case ColumnKindBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return invalidValue()
+		}
+		v.Value = b
+	case ColumnKindTimestamp:
+		for _, layout := range timestampLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				v.Value = t
+				return nil
+			}
+		}
+		return invalidValue()
+	case ColumnKindEnum:
+		if !contains(columnType.EnumValues, s) {
+			return fmt.Errorf("%s, valid values are: %v", invalidValue(), columnType.EnumValues)
+		}
+	}
+	return nil
+}
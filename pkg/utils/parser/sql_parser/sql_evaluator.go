@@ -0,0 +1,640 @@
+package sql_parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Predicate - a compiled query. Returns true if the received object (a map or a struct) matches the query.
+type Predicate func(obj interface{}) bool
+
+type EvaluatorOption func(e *evaluator)
+
+// WithFieldTag - the struct tag used to match the column names with the struct fields, ignoring the case. Defaults to `json`.
+// Fields without the tag are matched by name, ignoring the case and the underscores (ie: `creation_timestamp` matches `CreationTimestamp`)
+func WithFieldTag(fieldTag string) EvaluatorOption {
+	return func(e *evaluator) {
+		e.fieldTag = fieldTag
+	}
+}
+
+// Compile - compiles the received tree into a Predicate that evaluates the query against in-memory objects.
+// The semantic follows PostgreSQL: comparing NULL (a missing field or a nil value) with anything is never true.
+// Values are converted to the type of the field they are compared with (ie: `size > '10'` works with an int `size` field)
+func Compile(node Node, options ...EvaluatorOption) (Predicate, error) {
+	e := &evaluator{fieldTag: "json"}
+	for _, option := range options {
+		option(e)
+	}
+
+	cond, err := e.compile(node)
+	if err != nil {
+		return nil, err
+	}
+	return func(obj interface{}) bool {
+		return cond(obj) == ternaryTrue
+	}, nil
+}
+
+// ternary - the result of a SQL condition: TRUE, FALSE or UNKNOWN (NULL)
+type ternary int
+
+const (
+	ternaryFalse ternary = iota
+	ternaryTrue
+	ternaryUnknown
+)
+
+func ternaryOf(b bool) ternary {
+	if b {
+		return ternaryTrue
+	}
+	return ternaryFalse
+}
+
+func (t ternary) not() ternary {
+	switch t {
+	case ternaryTrue:
+		return ternaryFalse
+	case ternaryFalse:
+		return ternaryTrue
+	default:
+		return ternaryUnknown
+	}
+}
+
+func (t ternary) and(other ternary) ternary {
+	switch {
+	case t == ternaryFalse || other == ternaryFalse:
+		return ternaryFalse
+	case t == ternaryUnknown || other == ternaryUnknown:
+		return ternaryUnknown
+	default:
+		return ternaryTrue
+	}
+}
+
+func (t ternary) or(other ternary) ternary {
+	switch {
+	case t == ternaryTrue || other == ternaryTrue:
+		return ternaryTrue
+	case t == ternaryUnknown || other == ternaryUnknown:
+		return ternaryUnknown
+	default:
+		return ternaryFalse
+	}
+}
+
+// condition - a compiled boolean expression
+type condition func(obj interface{}) ternary
+
+// operand - a compiled column, JSONB path, function call or literal. Returns the value and false if the value is NULL
+type operand func(obj interface{}) (interface{}, bool)
+
+// jsonNull - the JSON `null` value. Differently from SQL NULL, `'null'::jsonb IS NULL` is false
+type jsonNull struct{}
+
+type evaluator struct {
+	fieldTag string
+}
+
+func (e *evaluator) compile(node Node) (condition, error) {
+	switch n := node.(type) {
+	case *LogicalNode:
+		left, err := e.compile(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.compile(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		if n.Operator == LogicalAnd {
+			return func(obj interface{}) ternary { return left(obj).and(right(obj)) }, nil
+		}
+		return func(obj interface{}) ternary { return left(obj).or(right(obj)) }, nil
+	case *GroupNode:
+		return e.compile(n.Expr)
+	case *ComparisonNode:
+		return e.compileComparison(n)
+	case *InNode:
+		left, err := e.compileOperand(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		return func(obj interface{}) ternary {
+			v, ok := left(obj)
+			if !ok {
+				return ternaryUnknown
+			}
+			res := ternaryFalse
+			for _, item := range n.Values {
+				res = res.or(compareWith(v, item.Value, func(c int) bool { return c == 0 }))
+			}
+			if n.Negated {
+				return res.not()
+			}
+			return res
+		}, nil
+	case *IsNullNode:
+		left, err := e.compileOperand(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		return func(obj interface{}) ternary {
+			_, ok := left(obj)
+			return ternaryOf(ok == n.Negated)
+		}, nil
+	case *BetweenNode:
+		left, err := e.compileOperand(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		if n.Lower == nil || n.Upper == nil {
+			return nil, fmt.Errorf("missing value for operator 'BETWEEN'")
+		}
+		return func(obj interface{}) ternary {
+			v, ok := left(obj)
+			if !ok {
+				return ternaryUnknown
+			}
+			res := compareWith(v, n.Lower.Value, func(c int) bool { return c >= 0 }).
+				and(compareWith(v, n.Upper.Value, func(c int) bool { return c <= 0 }))
+			if n.Negated {
+				return res.not()
+			}
+			return res
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported node type %T", node)
+	}
+}
+
+func (e *evaluator) compileComparison(n *ComparisonNode) (condition, error) {
+	left, err := e.compileOperand(n.Left)
+	if err != nil {
+		return nil, err
+	}
+	if n.Value == nil {
+		return nil, fmt.Errorf("missing value for operator '%s'", n.Operator)
+	}
+
+	var test func(v interface{}) ternary
+	switch n.Operator {
+	case OpEq:
+		test = func(v interface{}) ternary { return compareWith(v, n.Value.Value, func(c int) bool { return c == 0 }) }
+	case OpNotEq:
+		test = func(v interface{}) ternary { return compareWith(v, n.Value.Value, func(c int) bool { return c != 0 }) }
+	case OpGt:
+		test = func(v interface{}) ternary { return compareWith(v, n.Value.Value, func(c int) bool { return c > 0 }) }
+	case OpLt:
+		test = func(v interface{}) ternary { return compareWith(v, n.Value.Value, func(c int) bool { return c < 0 }) }
+	case OpGte:
+		test = func(v interface{}) ternary { return compareWith(v, n.Value.Value, func(c int) bool { return c >= 0 }) }
+	case OpLte:
+		test = func(v interface{}) ternary { return compareWith(v, n.Value.Value, func(c int) bool { return c <= 0 }) }
+	case OpLike, OpILike:
+		re, err := likeToRegexp(toText(n.Value.Value), n.Operator == OpILike)
+		if err != nil {
+			return nil, err
+		}
+		test = func(v interface{}) ternary { return ternaryOf(re.MatchString(toText(v))) }
+	case OpContains:
+		var expected interface{}
+		if err := json.Unmarshal([]byte(toText(n.Value.Value)), &expected); err != nil {
+			return nil, fmt.Errorf("invalid JSON value for operator '@>': %v", err)
+		}
+		test = func(v interface{}) ternary {
+			doc, ok := toJSON(v)
+			if !ok {
+				return ternaryUnknown
+			}
+			return ternaryOf(jsonContains(doc, expected, true))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operator '%s'", n.Operator)
+	}
+
+	return func(obj interface{}) ternary {
+		v, ok := left(obj)
+		if !ok {
+			return ternaryUnknown
+		}
+		return test(v)
+	}, nil
+}
+
+func (e *evaluator) compileOperand(node Node) (operand, error) {
+	switch n := node.(type) {
+	case *ColumnNode:
+		return func(obj interface{}) (interface{}, bool) { return e.lookup(obj, n.Name) }, nil
+	case *JSONBPathNode:
+		if n.Column == nil {
+			return nil, fmt.Errorf("missing column for JSONB path")
+		}
+		return func(obj interface{}) (interface{}, bool) {
+			v, ok := e.lookup(obj, n.Column.Name)
+			if !ok {
+				return nil, false
+			}
+			doc, ok := toJSON(v)
+			if !ok {
+				return nil, false
+			}
+			for _, key := range n.Keys {
+				object, isObject := doc.(map[string]interface{})
+				if !isObject {
+					return nil, false
+				}
+				if doc, ok = object[key]; !ok {
+					return nil, false
+				}
+				if doc == nil {
+					doc = jsonNull{}
+				}
+			}
+			if !n.AsText {
+				return doc, true
+			}
+			// `->>`: JSON null is SQL NULL, strings are unquoted and everything else is rendered as JSON text
+			switch d := doc.(type) {
+			case jsonNull:
+				return nil, false
+			case string:
+				return d, true
+			default:
+				b, _ := json.Marshal(d)
+				return string(b), true
+			}
+		}, nil
+	case *FunctionNode:
+		return e.compileFunction(n)
+	case *ValueNode:
+		return func(interface{}) (interface{}, bool) { return n.Value, true }, nil
+	default:
+		return nil, fmt.Errorf("unsupported operand type %T", node)
+	}
+}
+
+// evaluatorFunctions - the functions supported by the evaluator
+var evaluatorFunctions = map[string]func(args []interface{}) interface{}{
+	"lower": func(args []interface{}) interface{} { return strings.ToLower(toText(args[0])) },
+	"upper": func(args []interface{}) interface{} { return strings.ToUpper(toText(args[0])) },
+}
+
+func (e *evaluator) compileFunction(n *FunctionNode) (operand, error) {
+	f, ok := evaluatorFunctions[n.Name]
+	if !ok {
+		return nil, fmt.Errorf("function '%s' is not supported by the evaluator", n.Name)
+	}
+	if len(n.Args) != 1 {
+		return nil, fmt.Errorf("function '%s' expects 1 argument(s), got %d", n.Name, len(n.Args))
+	}
+
+	var args []operand
+	for _, arg := range n.Args {
+		a, err := e.compileOperand(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+
+	return func(obj interface{}) (interface{}, bool) {
+		values := make([]interface{}, 0, len(args))
+		for _, arg := range args {
+			v, ok := arg(obj)
+			if !ok {
+				// like in PostgreSQL, lower(NULL) and upper(NULL) are NULL
+				return nil, false
+			}
+			values = append(values, v)
+		}
+		return f(values), true
+	}, nil
+}
+
+// lookup returns the value of the column. Dotted names (ie: `cluster.name`) are looked up as nested fields when
+// the object doesn't contain the whole name.
+func (e *evaluator) lookup(obj interface{}, name string) (interface{}, bool) {
+	v, ok := e.field(reflect.ValueOf(obj), name)
+	if !ok && strings.Contains(name, ".") {
+		v = reflect.ValueOf(obj)
+		for _, part := range strings.Split(name, ".") {
+			if v, ok = e.field(v, part); !ok {
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+	v = indirect(v)
+	if !v.IsValid() || (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// field returns the field (or the map entry) with the received name
+func (e *evaluator) field(v reflect.Value, name string) (reflect.Value, bool) {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		if res := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); res.IsValid() {
+			return res, true
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if strings.EqualFold(iter.Key().String(), name) {
+				return iter.Value(), true
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tagName, _, _ := strings.Cut(f.Tag.Get(e.fieldTag), ",")
+			switch {
+			case tagName != "" && strings.EqualFold(tagName, name):
+				return v.Field(i), true
+			case tagName == "" && f.Anonymous:
+				if res, ok := e.field(v.Field(i), name); ok {
+					return res, true
+				}
+			case tagName == "" && strings.EqualFold(f.Name, strings.ReplaceAll(name, "_", "")):
+				return v.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// compareWith compares the value of the field with the literal converting the literal to the type of the field.
+// Returns UNKNOWN if the values can't be compared.
+func compareWith(field interface{}, literal interface{}, test func(c int) bool) ternary {
+	c, ok := compareValues(field, literal)
+	if !ok {
+		return ternaryUnknown
+	}
+	return ternaryOf(test(c))
+}
+
+func compareValues(field interface{}, literal interface{}) (int, bool) {
+	if t, ok := field.(time.Time); ok {
+		lt, ok := toTime(literal)
+		if !ok {
+			return 0, false
+		}
+		return t.Compare(lt), true
+	}
+
+	v := reflect.ValueOf(field)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if li, ok := toInt64(literal); ok {
+			return compareOrdered(v.Int(), li), true
+		}
+		lf, ok := toFloat64(literal)
+		return compareOrdered(float64(v.Int()), lf), ok
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		lf, ok := toFloat64(literal)
+		return compareOrdered(float64(v.Uint()), lf), ok
+	case reflect.Float32, reflect.Float64:
+		lf, ok := toFloat64(literal)
+		return compareOrdered(v.Float(), lf), ok
+	case reflect.Bool:
+		lb, ok := toBool(literal)
+		if !ok {
+			return 0, false
+		}
+		return compareOrdered(boolToInt(v.Bool()), boolToInt(lb)), true
+	case reflect.String:
+		return strings.Compare(v.String(), toText(literal)), true
+	}
+	return 0, false
+}
+
+func compareOrdered[T int64 | float64 | int](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.String:
+		i, err := strconv.ParseInt(v.String(), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range timestampLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func toText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toJSON converts the value to its generic JSON representation (maps, slices, strings, float64 and bool).
+// Strings and byte slices are expected to contain a JSON document.
+func toJSON(value interface{}) (interface{}, bool) {
+	var raw []byte
+	switch v := value.(type) {
+	case jsonNull, map[string]interface{}, []interface{}, float64, bool:
+		return v, true
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		raw = b
+	}
+
+	var ret interface{}
+	if err := json.Unmarshal(raw, &ret); err != nil {
+		return nil, false
+	}
+	if ret == nil {
+		return jsonNull{}, true
+	}
+	return ret, true
+}
+
+// jsonContains implements the PostgreSQL `@>` operator
+func jsonContains(doc interface{}, expected interface{}, topLevel bool) bool {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		d, ok := doc.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, ev := range e {
+			dv, ok := d[k]
+			if !ok || !jsonContains(dv, ev, false) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		d, ok := doc.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, ev := range e {
+			found := false
+			for _, dv := range d {
+				if _, isArray := ev.([]interface{}); isArray {
+					if _, isArray := dv.([]interface{}); !isArray {
+						continue
+					}
+				}
+				if jsonContains(dv, ev, false) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		// scalars: a top level array contains a scalar if one of its elements is equal to it
+		if d, ok := doc.([]interface{}); ok && topLevel {
+			for _, dv := range d {
+				if reflect.DeepEqual(dv, expected) {
+					return true
+				}
+			}
+			return false
+		}
+		if _, isNull := doc.(jsonNull); isNull {
+			return expected == nil
+		}
+		return reflect.DeepEqual(doc, expected)
+	}
+}
+
+// likeToRegexp converts a LIKE pattern to a regular expression. `%` matches any sequence of characters,
+// `_` matches any single character and `\` escapes the next character
+func likeToRegexp(pattern string, caseInsensitive bool) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("(?s")
+	if caseInsensitive {
+		sb.WriteString("i")
+	}
+	sb.WriteString(")^")
+
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '%':
+			sb.WriteString(".*")
+		case c == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("LIKE pattern must not end with escape character")
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,140 @@
+package sql_parser
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evaluator", func() {
+	type region struct {
+		ID string `json:"id"`
+	}
+	type cluster struct {
+		Name              string                 `json:"name"`
+		Size              int                    `json:"size"`
+		Enabled           bool                   `json:"enabled"`
+		CreationTimestamp time.Time              // matched by name
+		Owner             *string                `json:"owner,omitempty"`
+		Region            region                 `json:"region"`
+		Labels            map[string]interface{} `json:"labels"`
+		Manifest          string                 `json:"manifest"`
+	}
+
+	owner := "mickey"
+	clusterStruct := cluster{
+		Name:              "my-Cluster_1",
+		Size:              10,
+		Enabled:           true,
+		CreationTimestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		Owner:             &owner,
+		Region:            region{ID: "us-east-1"},
+		Labels:            map[string]interface{}{"env": "prod", "tier": map[string]interface{}{"level": 2.0}, "empty": nil},
+		Manifest:          `{"data":{"items":[{"name":"a","tags":["x","y"]},{"name":"b"}]}}`,
+	}
+	clusterMap := map[string]interface{}{
+		"name":               "my-Cluster_1",
+		"size":               10,
+		"enabled":            true,
+		"creation_timestamp": time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		"owner":              "mickey",
+		"region":             map[string]interface{}{"id": "us-east-1"},
+		"labels":             map[string]interface{}{"env": "prod", "tier": map[string]interface{}{"level": 2.0}, "empty": nil},
+		"manifest":           `{"data":{"items":[{"name":"a","tags":["x","y"]},{"name":"b"}]}}`,
+	}
+
+	DescribeTable("Evaluating", func(qry string, expected bool) {
+		tree, err := NewSQLParser(WithAllowedFunctions(Function("lower", 1), Function("upper", 1))).ParseToAST(qry)
+		Expect(err).ToNot(HaveOccurred())
+		predicate, err := Compile(tree)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(predicate(clusterStruct)).To(Equal(expected), "struct")
+		Expect(predicate(&clusterStruct)).To(Equal(expected), "struct pointer")
+		Expect(predicate(clusterMap)).To(Equal(expected), "map")
+	},
+		Entry("equal", "name = 'my-Cluster_1'", true),
+		Entry("not equal", "name <> 'my-Cluster_1'", false),
+		Entry("numbers are compared as numbers", "size > 9 and size < '11' and size >= 10 and size <= 10", true),
+		Entry("strings are compared as strings", "name > 'my-A'", true),
+		Entry("bool", "enabled = true and enabled <> false", true),
+		Entry("timestamp", "creation_timestamp > '2024-01-01' and creation_timestamp < '2024-02-01T00:00:00Z'", true),
+		Entry("LIKE", "name like 'my-%\\_1'", true),
+		Entry("LIKE is case sensitive", "name like 'my-cluster%'", false),
+		Entry("LIKE single char", "name like 'my-Clust_r_1'", true),
+		Entry("ILIKE", "name ilike 'MY-CLUSTER%'", true),
+		Entry("IN", "size in (1, 10, 100)", true),
+		Entry("NOT IN", "size not in (1, 10, 100)", false),
+		Entry("BETWEEN", "creation_timestamp between '2024-01-01' and '2024-01-31'", true),
+		Entry("NOT BETWEEN", "size not between 1 and 5", true),
+		Entry("nested field", "region.id = 'us-east-1'", true),
+		Entry("precedence", "name = 'x' or size = 10 and enabled = true", true),
+		Entry("braces", "(name = 'x' or size = 10) and enabled = false", false),
+		Entry("IS NULL on missing field", "missing IS NULL", true),
+		Entry("comparisons with NULL are never true", "missing = 'a' or missing <> 'a'", false),
+		Entry("NOT IN with NULL is not true", "missing not in ('a')", false),
+		Entry("IS NOT NULL", "owner is not null", true),
+		Entry("JSONB ->>", "labels -> 'tier' ->> 'level' = '2'", true),
+		Entry("JSONB ->> missing key", "labels -> 'tier' ->> 'missing' IS NULL", true),
+		Entry("JSONB ->> on a scalar is NULL", "labels -> 'env' ->> 'x' IS NULL and labels -> 'tier' ->> 'level' IS NOT NULL", true),
+		Entry("JSONB on a JSON string", "manifest -> 'data' -> 'items' @> '[{\"name\":\"b\"}]'", true),
+		Entry("JSONB @> nested arrays", "manifest -> 'data' -> 'items' @> '[{\"tags\":[\"y\"]}]'", true),
+		Entry("JSONB @> not contained", "manifest -> 'data' -> 'items' @> '[{\"name\":\"c\"}]'", false),
+		Entry("lower", "lower(name) = 'my-cluster_1'", true),
+		Entry("upper of NULL", "upper(missing) IS NULL and upper(name) like 'MY-%'", true),
+		Entry("JSONB @> object", "labels -> 'tier' @> '{\"level\":2}'", true),
+	)
+
+	It("Matches nil objects only with IS NULL", func() {
+		tree, err := NewSQLParser().ParseToAST("name IS NULL")
+		Expect(err).ToNot(HaveOccurred())
+		predicate, err := Compile(tree)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(predicate(nil)).To(BeTrue())
+		Expect(predicate(map[string]interface{}{"name": nil})).To(BeTrue())
+		Expect(predicate(map[string]interface{}{"name": "a"})).To(BeFalse())
+	})
+
+	It("Uses the configured field tag", func() {
+		type item struct {
+			Name string `db:"item_name"`
+		}
+		tree, err := NewSQLParser().ParseToAST("item_name = 'a'")
+		Expect(err).ToNot(HaveOccurred())
+		predicate, err := Compile(tree, WithFieldTag("db"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(predicate(item{Name: "a"})).To(BeTrue())
+	})
+
+	It("Matches the field tags ignoring the case", func() {
+		// the parser lowercases the column names
+		type item struct {
+			CreationTimestamp time.Time `json:"creationTimestamp"`
+			DisplayName       string    `json:"displayName,omitempty"`
+		}
+		tree, err := NewSQLParser().ParseToAST("creationTimestamp > '2024-01-01' and displayName = 'a'")
+		Expect(err).ToNot(HaveOccurred())
+		predicate, err := Compile(tree)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(predicate(item{CreationTimestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), DisplayName: "a"})).To(BeTrue())
+		Expect(predicate(item{CreationTimestamp: time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), DisplayName: "a"})).To(BeFalse())
+	})
+
+	It("Fails compiling unsupported functions", func() {
+		tree, err := NewSQLParser(WithAllowedFunctions(Function("date_trunc", 2))).ParseToAST("date_trunc('day', creation_timestamp) = '2024-01-01'")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = Compile(tree)
+		Expect(err).To(MatchError("function 'date_trunc' is not supported by the evaluator"))
+	})
+
+	It("Fails compiling invalid JSON", func() {
+		// the parser refuses invalid JSON values: build the tree by hand
+		tree := &ComparisonNode{
+			Left:     &JSONBPathNode{Column: &ColumnNode{Name: "labels"}, Keys: []string{"a"}},
+			Operator: OpContains,
+			Value:    &ValueNode{Value: "{invalid", Quoted: true},
+		}
+		_, err := Compile(tree)
+		Expect(err).To(HaveOccurred())
+	})
+})
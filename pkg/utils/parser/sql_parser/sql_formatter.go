@@ -0,0 +1,165 @@
+package sql_parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// astFormatter - renders an AST to its canonical textual form: keywords are uppercase, values are quoted and inlined,
+// tokens are separated by a single space and only the braces needed to preserve the precedence are kept
+type astFormatter struct {
+	sb strings.Builder
+}
+
+func (f *astFormatter) format(node Node) error {
+	switch n := node.(type) {
+	case *LogicalNode:
+		if err := f.formatOperand(n.Left, n.Operator); err != nil {
+			return err
+		}
+		f.sb.WriteString(" " + string(n.Operator) + " ")
+		return f.formatOperand(n.Right, n.Operator)
+	case *GroupNode:
+		// braces are added only where needed by formatOperand
+		return f.format(n.Expr)
+	case *ComparisonNode:
+		if err := f.format(n.Left); err != nil {
+			return err
+		}
+		if n.Value == nil {
+			return fmt.Errorf("missing value for operator '%s'", n.Operator)
+		}
+		f.sb.WriteString(" " + string(n.Operator) + " ")
+		return f.format(n.Value)
+	case *InNode:
+		if err := f.format(n.Left); err != nil {
+			return err
+		}
+		if n.Negated {
+			f.sb.WriteString(" NOT")
+		}
+		if len(n.Values) == 0 {
+			return fmt.Errorf("empty IN list")
+		}
+		f.sb.WriteString(" IN (")
+		for i, v := range n.Values {
+			if i > 0 {
+				f.sb.WriteString(", ")
+			}
+			if err := f.format(v); err != nil {
+				return err
+			}
+		}
+		f.sb.WriteString(")")
+	case *IsNullNode:
+		if err := f.format(n.Left); err != nil {
+			return err
+		}
+		f.sb.WriteString(" IS")
+		if n.Negated {
+			f.sb.WriteString(" NOT")
+		}
+		f.sb.WriteString(" NULL")
+	case *BetweenNode:
+		if err := f.format(n.Left); err != nil {
+			return err
+		}
+		if n.Negated {
+			f.sb.WriteString(" NOT")
+		}
+		if n.Lower == nil || n.Upper == nil {
+			return fmt.Errorf("missing value for operator 'BETWEEN'")
+		}
+		f.sb.WriteString(" BETWEEN ")
+		if err := f.format(n.Lower); err != nil {
+			return err
+		}
+		f.sb.WriteString(" AND ")
+		return f.format(n.Upper)
+	case *ColumnNode:
+		f.sb.WriteString(n.Name)
+	case *JSONBPathNode:
+		if err := f.format(n.Column); err != nil {
+			return err
+		}
+		for i, key := range n.Keys {
+			if n.AsText && i == len(n.Keys)-1 {
+				f.sb.WriteString(" ->> ")
+			} else {
+				f.sb.WriteString(" -> ")
+			}
+			quoted, err := formatQuoted(key)
+			if err != nil {
+				return err
+			}
+			f.sb.WriteString(quoted)
+		}
+	case *FunctionNode:
+		f.sb.WriteString(n.Name + "(")
+		for i, arg := range n.Args {
+			if i > 0 {
+				f.sb.WriteString(", ")
+			}
+			if err := f.format(arg); err != nil {
+				return err
+			}
+		}
+		f.sb.WriteString(")")
+	case *ValueNode:
+		quoted, err := formatQuoted(formatValue(n.Value))
+		if err != nil {
+			return err
+		}
+		f.sb.WriteString(quoted)
+	default:
+		return fmt.Errorf("unsupported node type %T", node)
+	}
+	return nil
+}
+
+// formatOperand formats the operand of a logical operator adding braces only when they are needed to preserve the precedence
+func (f *astFormatter) formatOperand(node Node, parentOperator LogicalOperator) error {
+	for {
+		group, ok := node.(*GroupNode)
+		if !ok {
+			break
+		}
+		node = group.Expr
+	}
+	if child, ok := node.(*LogicalNode); ok && parentOperator == LogicalAnd && child.Operator == LogicalOr {
+		f.sb.WriteString("(")
+		if err := f.format(child); err != nil {
+			return err
+		}
+		f.sb.WriteString(")")
+		return nil
+	}
+	return f.format(node)
+}
+
+// formatQuoted quotes the value for Format. The scanner can't read a backslash just before the closing quote (it would
+// escape the quote), so the values ending with a backslash are rejected instead of being formatted to a different query
+func formatQuoted(value string) (string, error) {
+	if strings.HasSuffix(value, `\`) {
+		return "", fmt.Errorf("the value `%s` can't be formatted: it ends with a backslash", value)
+	}
+	return quote(value), nil
+}
+
+// formatValue converts the (possibly coerced) value back to the string that would produce it when parsed
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,64 @@
+package sql_parser
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Format", func() {
+	parser := NewSQLParser(
+		WithAllowedFunctions(Function("lower", 1)),
+		WithColumnTypes(map[string]ColumnType{"size": IntColumn(), "creation_timestamp": TimestampColumn()}),
+	)
+
+	DescribeTable("Canonical form", func(qry string, expected string) {
+		formatted, err := parser.Format(qry)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(formatted).To(Equal(expected))
+
+		// the canonical form is stable
+		Expect(parser.Format(formatted)).To(Equal(expected))
+
+		// and gives back the same values
+		_, values, err := parser.Parse(qry)
+		Expect(err).ToNot(HaveOccurred())
+		formattedQry, formattedValues, err := parser.Parse(formatted)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(formattedValues).To(Equal(values))
+
+		// and the same tree for the same canonical form
+		canonicalTree, err := parser.ParseToAST(formatted)
+		Expect(err).ToNot(HaveOccurred())
+		canonicalQry, canonicalValues, err := parser.Render(canonicalTree)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(canonicalQry).To(Equal(formattedQry))
+		Expect(canonicalValues).To(Equal(values))
+	},
+		Entry("keywords and spaces", "Name='a'   and  owner   like  b", "name = 'a' AND owner LIKE 'b'"),
+		Entry("redundant braces", "((name = a)) and ((owner = b and region = c))", "name = 'a' AND owner = 'b' AND region = 'c'"),
+		Entry("needed braces are kept", "(name = a or owner = b) and (region = c)", "(name = 'a' OR owner = 'b') AND region = 'c'"),
+		Entry("AND inside OR does not need braces", "(name = a and owner = b) or region = c", "name = 'a' AND owner = 'b' OR region = 'c'"),
+		Entry("IN list", "owner not in (a,'b' ,  'c')", "owner NOT IN ('a', 'b', 'c')"),
+		Entry("escaped quotes", `name = 'it\'s' or name = '@,\\'""(){}/'`, `name = 'it\'s' OR name = '@,\\'""(){}/'`),
+		Entry("embedded backslashes", `name = 'a\b\\c' or name = x\y or name = 'a\\''`, `name = 'a\b\\c' OR name = 'x\y' OR name = 'a\\''`),
+		Entry("IS NULL and BETWEEN", "owner is not null and size not between 1 and '10'", "owner IS NOT NULL AND size NOT BETWEEN '1' AND '10'"),
+		Entry("timestamps", "creation_timestamp > '2024-01-01'", "creation_timestamp > '2024-01-01T00:00:00Z'"),
+		Entry("JSONB", `manifest->'data'->>'foo' ilike '%x%' or manifest -> 'data' @> '{"a": 1}'`,
+			`manifest -> 'data' ->> 'foo' ILIKE '%x%' OR manifest -> 'data' @> '{"a": 1}'`),
+		Entry("functions", "LOWER(Name) = 'a'", "lower(name) = 'a'"),
+	)
+
+	DescribeTable("Rejects the values ending with a backslash", func(qry string, expected string) {
+		// they would be formatted as an escaped closing quote
+		_, err := parser.Format(qry)
+		Expect(err).To(MatchError(expected))
+	},
+		Entry("trailing backslash", `name = x\`, "the value `x\\` can't be formatted: it ends with a backslash"),
+		Entry("backslash before another condition", `name = x\ or owner = 'b'`, "the value `x\\` can't be formatted: it ends with a backslash"),
+	)
+
+	It("Returns parsing errors", func() {
+		_, err := parser.Format("name = ")
+		Expect(err).To(MatchError("EOF encountered while parsing string"))
+	})
+})
@@ -0,0 +1,30 @@
+package sql_parser
+
+import "fmt"
+
+// SQLFunction - a function that can be called in the query (see WithAllowedFunctions)
+type SQLFunction struct {
+	Name string
+	// MinArgs and MaxArgs - the accepted number of arguments. A negative MaxArgs means no upper limit.
+	MinArgs int
+	MaxArgs int
+}
+
+// Function - returns a SQLFunction accepting exactly `args` arguments
+func Function(name string, args int) SQLFunction {
+	return SQLFunction{Name: name, MinArgs: args, MaxArgs: args}
+}
+
+func (f SQLFunction) checkArgs(args int) error {
+	if args < f.MinArgs || (f.MaxArgs >= 0 && args > f.MaxArgs) {
+		expected := fmt.Sprintf("%d", f.MinArgs)
+		switch {
+		case f.MaxArgs < 0:
+			expected = fmt.Sprintf("at least %d", f.MinArgs)
+		case f.MaxArgs != f.MinArgs:
+			expected = fmt.Sprintf("%d to %d", f.MinArgs, f.MaxArgs)
+		}
+		return fmt.Errorf("function '%s' expects %s argument(s), got %d", f.Name, expected, args)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package sql_parser
 
 import (
+	"strings"
+
 	. "github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
 	. "github.com/openshift-online/ocm-common/pkg/utils/parser/string_parser"
 )
@@ -10,6 +12,7 @@ const (
 	opTokenFamily        = "OP"
 	logicalOpTokenFamily = "LOGICAL"
 	columnTokenFamily    = "COLUMN"
+	functionTokenFamily  = "FUNCTION"
 
 	othersTokenFamily      = "OTHERS"
 	valueTokenFamily       = "VALUE"
@@ -35,6 +38,22 @@ const (
 	and                    = "AND"
 	or                     = "OR"
 	not                    = "NOT"
+	is                     = "IS"
+	isNot                  = "IS_NOT"
+	null                   = "NULL"
+	between                = "BETWEEN"
+	betweenLowerValue      = "BETWEEN_LOWER_VALUE"
+	betweenLowerQuoted     = "BETWEEN_LOWER_QUOTED_VALUE"
+	betweenAnd             = "BETWEEN_AND"
+	betweenUpperValue      = "BETWEEN_UPPER_VALUE"
+	betweenUpperQuoted     = "BETWEEN_UPPER_QUOTED_VALUE"
+	function               = "FUNCTION"
+	functionOpenBrace      = "FUNCTION_OPEN_BRACE"
+	functionClosedBrace    = "FUNCTION_CLOSED_BRACE"
+	functionComma          = "FUNCTION_COMMA"
+	functionArgColumn      = "FUNCTION_ARG_COLUMN"
+	functionArgValue       = "FUNCTION_ARG_VALUE"
+	functionArgQuoted      = "FUNCTION_ARG_QUOTED_VALUE"
 
 	// Define the names of the tokens to be parsed
 
@@ -46,40 +65,59 @@ const (
 	jsonbFieldToStringify = "JSONB_FIELD_TO_STRINGIFY" // The field that will contain the `string` value, ie: ->> FIELD
 )
 
-func BasicSQLGrammar() Grammar {
+// BasicSQLGrammar - the grammar of the WHERE clauses. `allowedFunctions` are the names of the functions that can be called
+// on the left side of a comparison, ie: `lower(name) = 'value'`. Function names take precedence over column names.
+func BasicSQLGrammar(allowedFunctions ...string) Grammar {
 	grammar := Grammar{
 		Tokens: []TokenDefinition{
-			{Name: openBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`(`)},
-			{Name: closedBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`)`)},
+			{Name: openBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`(`), Examples: []string{`(`}},
+			{Name: closedBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`)`), Examples: []string{`)`}},
+			{Name: function, StateData: functionTokenFamily, Acceptor: functionAcceptor(allowedFunctions)},
+			{Name: functionOpenBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`(`), Examples: []string{`(`}},
+			{Name: functionClosedBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`)`), Examples: []string{`)`}},
+			{Name: functionComma, Acceptor: StringAcceptor(`,`), Examples: []string{`,`}},
+			{Name: functionArgColumn, StateData: columnTokenFamily, Acceptor: RegexpAcceptor(`(?i)[A-Z][A-Z0-9_.]*`)},
+			{Name: functionArgQuoted, StateData: quotedValueTokenFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
+			{Name: functionArgValue, StateData: valueTokenFamily, Acceptor: RegexpAcceptor(`[^'() ,]*`)},
 			{Name: column, StateData: columnTokenFamily, Acceptor: RegexpAcceptor(`(?i)[A-Z][A-Z0-9_.]*`)},
 			{Name: value, StateData: valueTokenFamily, Acceptor: RegexpAcceptor(`[^'() ]*`)},
 			{Name: quotedValue, StateData: quotedValueTokenFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
-			{Name: eq, StateData: opTokenFamily, Acceptor: StringAcceptor(`=`)},
-			{Name: gt, StateData: opTokenFamily, Acceptor: StringAcceptor(`>`)},
-			{Name: lt, StateData: opTokenFamily, Acceptor: StringAcceptor(`<`)},
-			{Name: gte, StateData: opTokenFamily, Acceptor: StringAcceptor(`>=`)},
-			{Name: lte, StateData: opTokenFamily, Acceptor: StringAcceptor(`<=`)},
-			{Name: comma, Acceptor: StringAcceptor(`,`)},
-			{Name: notEq, StateData: opTokenFamily, Acceptor: StringAcceptor(`<>`)},
-			{Name: like, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)LIKE`)},
-			{Name: ilike, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)ILIKE`)},
-			{Name: in, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)IN`)},
-			{Name: listOpenBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`(`)},
+			{Name: eq, StateData: opTokenFamily, Acceptor: StringAcceptor(`=`), Examples: []string{`=`}},
+			{Name: gt, StateData: opTokenFamily, Acceptor: StringAcceptor(`>`), Examples: []string{`>`}},
+			{Name: lt, StateData: opTokenFamily, Acceptor: StringAcceptor(`<`), Examples: []string{`<`}},
+			{Name: gte, StateData: opTokenFamily, Acceptor: StringAcceptor(`>=`), Examples: []string{`>=`}},
+			{Name: lte, StateData: opTokenFamily, Acceptor: StringAcceptor(`<=`), Examples: []string{`<=`}},
+			{Name: comma, Acceptor: StringAcceptor(`,`), Examples: []string{`,`}},
+			{Name: notEq, StateData: opTokenFamily, Acceptor: StringAcceptor(`<>`), Examples: []string{`<>`}},
+			{Name: like, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)LIKE`), Examples: []string{`LIKE`}},
+			{Name: ilike, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)ILIKE`), Examples: []string{`ILIKE`}},
+			{Name: in, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)IN`), Examples: []string{`IN`}},
+			{Name: listOpenBrace, StateData: braceTokenFamily, Acceptor: StringAcceptor(`(`), Examples: []string{`(`}},
 			{Name: quotedValueInList, StateData: quotedValueTokenFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
 			{Name: valueInList, StateData: valueTokenFamily, Acceptor: RegexpAcceptor(`[^'() ]*`)},
-			{Name: and, StateData: logicalOpTokenFamily, Acceptor: RegexpAcceptor(`(?i)AND`)},
-			{Name: or, StateData: logicalOpTokenFamily, Acceptor: RegexpAcceptor(`(?i)OR`)},
-			{Name: not, StateData: logicalOpTokenFamily, Acceptor: RegexpAcceptor(`(?i)NOT`)},
-			{Name: jsonbArrow, StateData: jsonbFamily, Acceptor: StringAcceptor(`->`)},
+			{Name: and, StateData: logicalOpTokenFamily, Acceptor: RegexpAcceptor(`(?i)AND`), Examples: []string{`AND`}},
+			{Name: or, StateData: logicalOpTokenFamily, Acceptor: RegexpAcceptor(`(?i)OR`), Examples: []string{`OR`}},
+			{Name: not, StateData: logicalOpTokenFamily, Acceptor: RegexpAcceptor(`(?i)NOT`), Examples: []string{`NOT`}},
+			{Name: is, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)IS`), Examples: []string{`IS`}},
+			{Name: isNot, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)NOT`), Examples: []string{`NOT`}},
+			{Name: null, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)NULL`), Examples: []string{`NULL`}},
+			{Name: between, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)BETWEEN`), Examples: []string{`BETWEEN`}},
+			{Name: betweenLowerQuoted, StateData: quotedValueTokenFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
+			{Name: betweenLowerValue, StateData: valueTokenFamily, Acceptor: RegexpAcceptor(`[^'() ]*`)},
+			// the AND of a BETWEEN is not a logical operator: it must not be counted as a join
+			{Name: betweenAnd, StateData: opTokenFamily, Acceptor: RegexpAcceptor(`(?i)AND`), Examples: []string{`AND`}},
+			{Name: betweenUpperQuoted, StateData: quotedValueTokenFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
+			{Name: betweenUpperValue, StateData: valueTokenFamily, Acceptor: RegexpAcceptor(`[^'() ]*`)},
+			{Name: jsonbArrow, StateData: jsonbFamily, Acceptor: StringAcceptor(`->`), Examples: []string{`->`}},
 			{Name: jsonbField, StateData: jsonbFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
-			{Name: jsonbToString, StateData: jsonbFamily, Acceptor: StringAcceptor(`->>`)},
-			{Name: jsonbContains, StateData: jsonbFamily, Acceptor: StringAcceptor(`@>`)},
+			{Name: jsonbToString, StateData: jsonbFamily, Acceptor: StringAcceptor(`->>`), Examples: []string{`->>`}},
+			{Name: jsonbContains, StateData: jsonbFamily, Acceptor: StringAcceptor(`@>`), Examples: []string{`@>`}},
 			{Name: jsonbFieldToStringify, StateData: jsonbFamily, Acceptor: RegexpAcceptor(`'([^']|\\')*'`)},
 		},
 		Transitions: []TokenTransitions{
-			{TokenName: StartState, ValidTransitions: []string{column, openBrace}},
-			{TokenName: openBrace, ValidTransitions: []string{column, openBrace}},
-			{TokenName: column, ValidTransitions: []string{gt, lt, gte, lte, eq, notEq, like, ilike, in, not, jsonbArrow}},
+			{TokenName: StartState, ValidTransitions: []string{function, column, openBrace}},
+			{TokenName: openBrace, ValidTransitions: []string{function, column, openBrace}},
+			{TokenName: column, ValidTransitions: []string{gt, lt, gte, lte, eq, notEq, like, ilike, in, not, is, between, jsonbArrow}},
 			{TokenName: eq, ValidTransitions: []string{quotedValue, value}},
 			{TokenName: notEq, ValidTransitions: []string{quotedValue, value}},
 			{TokenName: gt, ValidTransitions: []string{quotedValue, value}},
@@ -91,9 +129,25 @@ func BasicSQLGrammar() Grammar {
 			{TokenName: quotedValue, ValidTransitions: []string{or, and, closedBrace, EndState}},
 			{TokenName: value, ValidTransitions: []string{or, and, closedBrace, EndState}},
 			{TokenName: closedBrace, ValidTransitions: []string{or, and, closedBrace, EndState}},
-			{TokenName: and, ValidTransitions: []string{column, openBrace}},
-			{TokenName: or, ValidTransitions: []string{column, openBrace}},
-			{TokenName: not, ValidTransitions: []string{in}},
+			{TokenName: and, ValidTransitions: []string{function, column, openBrace}},
+			{TokenName: or, ValidTransitions: []string{function, column, openBrace}},
+			{TokenName: function, ValidTransitions: []string{functionOpenBrace}},
+			{TokenName: functionOpenBrace, ValidTransitions: []string{functionArgColumn, functionArgQuoted, functionArgValue, functionClosedBrace}},
+			{TokenName: functionArgColumn, ValidTransitions: []string{functionComma, functionClosedBrace}},
+			{TokenName: functionArgQuoted, ValidTransitions: []string{functionComma, functionClosedBrace}},
+			{TokenName: functionArgValue, ValidTransitions: []string{functionComma, functionClosedBrace}},
+			{TokenName: functionComma, ValidTransitions: []string{functionArgColumn, functionArgQuoted, functionArgValue}},
+			{TokenName: functionClosedBrace, ValidTransitions: []string{gt, lt, gte, lte, eq, notEq, like, ilike, in, not, is, between}},
+			{TokenName: not, ValidTransitions: []string{in, between}},
+			{TokenName: is, ValidTransitions: []string{isNot, null}},
+			{TokenName: isNot, ValidTransitions: []string{null}},
+			{TokenName: null, ValidTransitions: []string{or, and, closedBrace, EndState}},
+			{TokenName: between, ValidTransitions: []string{betweenLowerQuoted, betweenLowerValue}},
+			{TokenName: betweenLowerQuoted, ValidTransitions: []string{betweenAnd}},
+			{TokenName: betweenLowerValue, ValidTransitions: []string{betweenAnd}},
+			{TokenName: betweenAnd, ValidTransitions: []string{betweenUpperQuoted, betweenUpperValue}},
+			{TokenName: betweenUpperQuoted, ValidTransitions: []string{or, and, closedBrace, EndState}},
+			{TokenName: betweenUpperValue, ValidTransitions: []string{or, and, closedBrace, EndState}},
 			{TokenName: in, ValidTransitions: []string{listOpenBrace}},
 			{TokenName: listOpenBrace, ValidTransitions: []string{quotedValueInList, valueInList}},
 			{TokenName: quotedValueInList, ValidTransitions: []string{comma, closedBrace}},
@@ -102,10 +156,22 @@ func BasicSQLGrammar() Grammar {
 			{TokenName: jsonbArrow, ValidTransitions: []string{jsonbField}},
 			{TokenName: jsonbField, ValidTransitions: []string{jsonbArrow, jsonbToString, jsonbContains}},
 			{TokenName: jsonbToString, ValidTransitions: []string{jsonbFieldToStringify}},
-			{TokenName: jsonbFieldToStringify, ValidTransitions: []string{eq, notEq, like, ilike, in, not}},
+			{TokenName: jsonbFieldToStringify, ValidTransitions: []string{eq, notEq, like, ilike, in, not, is, between}},
 			{TokenName: jsonbContains, ValidTransitions: []string{quotedValue}},
 		},
 	}
 
 	return grammar
 }
+
+// functionAcceptor accepts the names of the allowed functions, ignoring the case
+func functionAcceptor(allowedFunctions []string) Acceptor[string] {
+	return func(value string) bool {
+		for _, f := range allowedFunctions {
+			if strings.EqualFold(f, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,89 @@
+package sql_parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSONBSchema - restricts the JSONB paths that can be used with a JSONB column
+type JSONBSchema struct {
+	// AllowedPaths - the allowed key paths, expressed as dot separated keys (ie: `metadata.labels.env`). A `*` key
+	// matches any key (ie: `metadata.labels.*`). When empty, any path is allowed.
+	AllowedPaths []string
+	// MaximumDepth - the maximum number of keys of a path. 0 means no limit.
+	MaximumDepth int
+}
+
+type JSONBOption func(schema *JSONBSchema)
+
+// WithJSONBPaths - the key paths that can be used with the column, see JSONBSchema.AllowedPaths
+func WithJSONBPaths(paths ...string) JSONBOption {
+	return func(schema *JSONBSchema) {
+		schema.AllowedPaths = paths
+	}
+}
+
+// WithJSONBMaximumDepth - the maximum number of keys of the paths used with the column
+func WithJSONBMaximumDepth(maximumDepth int) JSONBOption {
+	return func(schema *JSONBSchema) {
+		schema.MaximumDepth = maximumDepth
+	}
+}
+
+// allows returns true if the received keys match one of the allowed paths
+func (s *JSONBSchema) allows(keys []string) bool {
+	if len(s.AllowedPaths) == 0 {
+		return true
+	}
+	for _, allowedPath := range s.AllowedPaths {
+		if matchJSONBPath(strings.Split(allowedPath, "."), keys) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchJSONBPath(pattern []string, keys []string) bool {
+	if len(pattern) != len(keys) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != keys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkJSONBPath validates the path against the schema of its column. When complete is false, the path is still being
+// parsed and only its depth is checked.
+func (r columnTypeRegistry) checkJSONBPath(operand Node, complete bool) error {
+	path, ok := operand.(*JSONBPathNode)
+	if !ok {
+		return nil
+	}
+	columnType, ok := r[path.Column.Name]
+	if !ok || columnType.JSONB == nil {
+		return nil
+	}
+
+	schema := columnType.JSONB
+	if schema.MaximumDepth > 0 && len(path.Keys) > schema.MaximumDepth {
+		return fmt.Errorf("maximum JSONB path depth (%d) exceeded for column '%s'", schema.MaximumDepth, path.Column.Name)
+	}
+	if complete && !schema.allows(path.Keys) {
+		return fmt.Errorf("JSONB path '%s' is not allowed for column '%s', valid values are: %v",
+			strings.Join(path.Keys, "."), path.Column.Name, schema.AllowedPaths)
+	}
+	return nil
+}
+
+// checkJSONValue returns an error if the value of a `@>` comparison is not a valid JSON document
+func checkJSONValue(tokenValue string, v *ValueNode) error {
+	s, _ := v.Value.(string)
+	if !json.Valid([]byte(s)) {
+		return fmt.Errorf("invalid JSON value %s for operator '@>'", tokenValue)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package sql_parser
+
+import "fmt"
+
+// MaximumBraceDepthError - returned when the braces are nested deeper than configured with WithMaximumBraceDepth
+type MaximumBraceDepthError struct {
+	MaximumBraceDepth int
+}
+
+func (e *MaximumBraceDepthError) Error() string {
+	return fmt.Sprintf("maximum brace depth (%d) exceeded", e.MaximumBraceDepth)
+}
+
+// MaximumInListLengthError - returned when an IN list contains more values than configured with WithMaximumInListLength
+type MaximumInListLengthError struct {
+	MaximumInListLength int
+}
+
+func (e *MaximumInListLengthError) Error() string {
+	return fmt.Sprintf("maximum number of values in an IN list (%d) exceeded", e.MaximumInListLength)
+}
+
+// MaximumTokensError - returned when the query contains more tokens than configured with WithMaximumTokens
+type MaximumTokensError struct {
+	MaximumTokens int
+}
+
+func (e *MaximumTokensError) Error() string {
+	return fmt.Sprintf("maximum number of tokens (%d) exceeded", e.MaximumTokens)
+}
+
+// MaximumInputLengthError - returned when the query is longer than configured with WithMaximumInputLength
+type MaximumInputLengthError struct {
+	MaximumInputLength int
+	InputLength        int
+}
+
+func (e *MaximumInputLengthError) Error() string {
+	return fmt.Sprintf("maximum input length (%d) exceeded: %d", e.MaximumInputLength, e.InputLength)
+}
+
+// checkLimits enforces the limits configured in the parser on each token. A limit <= 0 means no limit. The input length
+// is checked by ParseToAST before scanning the string.
+func (p *sqlParseContext) checkLimits(to string) error {
+	limits := p.config
+
+	p.tokens++
+	if limits.maximumTokens > 0 && p.tokens > limits.maximumTokens {
+		return &MaximumTokensError{MaximumTokens: limits.maximumTokens}
+	}
+	if limits.maximumBraceDepth > 0 && p.openBraces > limits.maximumBraceDepth {
+		return &MaximumBraceDepthError{MaximumBraceDepth: limits.maximumBraceDepth}
+	}
+
+	switch to {
+	case in:
+		p.inListLength = 0
+	case valueInList, quotedValueInList:
+		p.inListLength++
+		if limits.maximumInListLength > 0 && p.inListLength > limits.maximumInListLength {
+			return &MaximumInListLengthError{MaximumInListLength: limits.maximumInListLength}
+		}
+	}
+	return nil
+}
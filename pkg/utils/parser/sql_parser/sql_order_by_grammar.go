@@ -0,0 +1,36 @@
+package sql_parser
+
+import (
+	. "github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+	. "github.com/openshift-online/ocm-common/pkg/utils/parser/string_parser"
+)
+
+const (
+	directionTokenFamily = "DIRECTION"
+
+	orderByColumn = "ORDER_BY_COLUMN"
+	asc           = "ASC"
+	desc          = "DESC"
+	orderByComma  = "ORDER_BY_COMMA"
+)
+
+// OrderByGrammar - the grammar of the ORDER BY clause (only the portion after `ORDER BY` is supported), ie: `name asc, creation_timestamp desc`
+func OrderByGrammar() Grammar {
+	grammar := Grammar{
+		Tokens: []TokenDefinition{
+			{Name: orderByColumn, StateData: columnTokenFamily, Acceptor: RegexpAcceptor(`(?i)[A-Z][A-Z0-9_.]*`)},
+			{Name: asc, StateData: directionTokenFamily, Acceptor: RegexpAcceptor(`(?i)ASC`), Examples: []string{`ASC`}},
+			{Name: desc, StateData: directionTokenFamily, Acceptor: RegexpAcceptor(`(?i)DESC`), Examples: []string{`DESC`}},
+			{Name: orderByComma, StateData: othersTokenFamily, Acceptor: StringAcceptor(`,`), Examples: []string{`,`}},
+		},
+		Transitions: []TokenTransitions{
+			{TokenName: StartState, ValidTransitions: []string{orderByColumn}},
+			{TokenName: orderByColumn, ValidTransitions: []string{asc, desc, orderByComma, EndState}},
+			{TokenName: asc, ValidTransitions: []string{orderByComma, EndState}},
+			{TokenName: desc, ValidTransitions: []string{orderByComma, EndState}},
+			{TokenName: orderByComma, ValidTransitions: []string{orderByColumn}},
+		},
+	}
+
+	return grammar
+}
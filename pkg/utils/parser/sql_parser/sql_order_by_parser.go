@@ -0,0 +1,100 @@
+package sql_parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_parser"
+)
+
+// OrderByParser - This object is to be used to parse and validate ORDER BY clauses (only portion after the `ORDER BY` is supported)
+type OrderByParser interface {
+	// Parse - parses the received ORDER BY string and returns the normalized ORDER BY clause or an error.
+	// Each column gets the column prefix (or its mapped expression) and an explicit ASC/DESC direction, ie:
+	// `name, creation_timestamp desc` => `name ASC, creation_timestamp DESC`
+	Parse(orderBy string) (string, error)
+}
+
+// orderByParser - the parser configuration. As for the sqlParser, the state of each parsing is kept into a dedicated
+// orderByParseContext
+type orderByParser struct {
+	parser        *string_parser.StringParser
+	validColumns  []string
+	columnPrefix  string
+	columnMapping map[string]string
+}
+
+var _ OrderByParser = &orderByParser{}
+
+// orderByParseContext - the state of a single Parse call
+type orderByParseContext struct {
+	config *orderByParser
+
+	// current parsing result
+	columns    []string
+	directions []string
+}
+
+func (p *orderByParser) Parse(orderBy string) (string, error) {
+	if strings.Trim(orderBy, " ") == "" {
+		return "", nil
+	}
+
+	ctx := &orderByParseContext{config: p}
+	if err := p.parser.ParseWithInterceptor(orderBy, ctx.transitionInterceptor); err != nil {
+		return "", err
+	}
+
+	renderer := astRenderer{columnPrefix: p.columnPrefix, columnMapping: p.columnMapping}
+	for i, columnName := range ctx.columns {
+		if i > 0 {
+			renderer.qry.WriteString(", ")
+		}
+		if err := renderer.render(&ColumnNode{Name: columnName}); err != nil {
+			return "", err
+		}
+		renderer.qry.WriteString(" " + ctx.directions[i])
+	}
+	return renderer.qry.String(), nil
+}
+
+func (p *orderByParseContext) transitionInterceptor(_, to *state_machine.State[string, string], tokenValue string) error {
+	switch to.Data() {
+	case columnTokenFamily:
+		columnName := strings.ToLower(tokenValue)
+		if len(p.config.validColumns) > 0 && !contains(p.config.validColumns, columnName) {
+			return fmt.Errorf("invalid column name: '%s', valid values are: %v", tokenValue, p.config.validColumns)
+		}
+		if contains(p.columns, columnName) {
+			return fmt.Errorf("column '%s' specified more than once", tokenValue)
+		}
+		p.columns = append(p.columns, columnName)
+		p.directions = append(p.directions, "ASC")
+	case directionTokenFamily:
+		p.directions[len(p.directions)-1] = strings.ToUpper(tokenValue)
+	}
+	return nil
+}
+
+// NewOrderByParser - creates a new OrderByParser. Only WithValidColumns, WithColumnPrefix and WithColumnMapping are
+// used by this parser: all the other options are ignored.
+func NewOrderByParser(options ...SQLParserOption) OrderByParser {
+	config := &sqlParser{}
+	for _, option := range options {
+		option(config)
+	}
+
+	parser := &orderByParser{
+		validColumns:  config.validColumns,
+		columnPrefix:  config.columnPrefix,
+		columnMapping: config.columnMapping,
+	}
+
+	parser.parser = string_parser.NewStringParserBuilder().
+		WithGrammar(OrderByGrammar()).
+		WithScannerFactory(NewSQLScanner).
+		Build()
+
+	return parser
+}
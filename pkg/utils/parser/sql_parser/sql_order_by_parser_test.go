@@ -0,0 +1,44 @@
+package sql_parser
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrderByParser", func() {
+	DescribeTable("Parsing", func(orderBy string, parser OrderByParser, expected string, errMessage string) {
+		res, err := parser.Parse(orderBy)
+		if errMessage != "" {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(errMessage))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(expected))
+	},
+		Entry("empty", "  ", NewOrderByParser(), "", ""),
+		Entry("single column", "name", NewOrderByParser(), "name ASC", ""),
+		Entry("multiple columns", "Name asc, creation_timestamp DESC,region",
+			NewOrderByParser(), "name ASC, creation_timestamp DESC, region ASC", ""),
+		Entry("column prefix and mapping", "name desc, region_id",
+			NewOrderByParser(
+				WithColumnPrefix("main"),
+				WithColumnMapping(map[string]string{"region_id": "regions.id"}),
+			), "main.name DESC, regions.id ASC", ""),
+		Entry("valid columns", "name, owner desc",
+			NewOrderByParser(WithValidColumns("name", "region")), "",
+			"[7] error parsing the filter: invalid column name: 'owner', valid values are: [name region]"),
+		Entry("duplicated column", "name, NAME desc",
+			NewOrderByParser(), "",
+			"[7] error parsing the filter: column 'NAME' specified more than once"),
+		Entry("invalid direction", "name up",
+			NewOrderByParser(), "",
+			"[6] error parsing the filter: unexpected token `up`"),
+		Entry("injection attempt", "name; drop table clusters",
+			NewOrderByParser(), "",
+			"[1] error parsing the filter: unexpected token `name;`"),
+		Entry("trailing comma", "name desc,",
+			NewOrderByParser(), "",
+			"EOF encountered while parsing string"),
+	)
+})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_parser"
+	"sort"
 	"strings"
 )
 
@@ -13,55 +14,152 @@ const defaultMaximumComplexity = 10
 type SQLParser interface {
 	// Parse - parses the received SQL string and returns the parsed values or an error
 	// Returns:
-	// - string: The parsed SQL replacing all the values with '?' placeholders
-	// - interface{}: All the values to pass to the database (to replace the '?' placeholders)
+	// - string: The parsed SQL replacing all the values with placeholders ('?' by default, see WithPlaceholderStyle)
+	// - interface{}: All the values to pass to the database (to replace the placeholders). This is a []interface{}
+	//   or, when using NamedPlaceholder, a map[string]interface{}
 	// - error: non nil in case of any error
 	Parse(sql string) (string, interface{}, error)
+	// ParseToAST - parses the received SQL string and returns the root of the parsed expression tree or an error
+	ParseToAST(sql string) (Node, error)
+	// Render - renders the received tree. The returned values have the same meaning of the values returned by Parse
+	Render(node Node) (string, interface{}, error)
+	// Format - parses the received SQL string and returns it in its canonical form: keywords are uppercase, column
+	// and function names are lowercase, all the values are quoted, tokens are separated by a single space and redundant
+	// braces are removed. The canonical form can be used as a cache key or in audit logs: parsing it gives back the same values.
+	Format(sql string) (string, error)
+	// Complete - returns the tokens that can follow the received prefix (ie: for the tab completion of a CLI).
+	// Column names are suggested from the WithValidColumns list and function names from WithAllowedFunctions.
+	// See string_parser.StringParser.Complete
+	Complete(prefix string) []string_parser.Suggestion
 }
 
+// sqlParser - the parser configuration. It is never modified after NewSQLParser returns, so that the parser can be
+// used concurrently: the state of each parsing is kept into a dedicated sqlParseContext
 type sqlParser struct {
-	// configuration
 	maximumComplexity int
 	parser            *string_parser.StringParser
+	validColumns      []string
+	columnPrefix      string
+	columnMapping     map[string]string
+	placeholderStyle  PlaceholderStyle
+	columnTypes       columnTypeRegistry
+	functions         map[string]SQLFunction
+
+	// limits, see sql_limits.go
+	maximumBraceDepth   int
+	maximumInListLength int
+	maximumTokens       int
+	maximumInputLength  int
+
+	// suggestionProvider - the provider configured with WithSuggestionProvider
+	suggestionProvider string_parser.SuggestionProvider
+}
+
+var _ SQLParser = &sqlParser{}
+
+// sqlParseContext - the state of a single Parse call
+type sqlParseContext struct {
+	config *sqlParser
 
-	// current parsing state
 	// counts the number of joins
 	complexity int
 	// counts the number of braces to be closed
-	openBraces   int
-	validColumns []string
-	columnPrefix string
+	openBraces int
+	// counts the tokens parsed so far
+	tokens int
+	// counts the values of the current IN list
+	inListLength int
 
 	// current parsing result
-	resultQry    string
-	resultValues []interface{}
+	builder *astBuilder
 }
 
-var _ SQLParser = &sqlParser{}
-
 func (p *sqlParser) Parse(sql string) (string, interface{}, error) {
-	p.reset()
+	tree, err := p.ParseToAST(sql)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return p.Render(tree)
+}
+
+func (p *sqlParser) ParseToAST(sql string) (Node, error) {
+	// checked before scanning the string, so that long strings are refused without being scanned
+	if p.maximumInputLength > 0 && len(sql) > p.maximumInputLength {
+		return nil, &string_parser.ParseError{
+			Err: &MaximumInputLengthError{MaximumInputLength: p.maximumInputLength, InputLength: len(sql)},
+		}
+	}
+
+	ctx := &sqlParseContext{
+		config:  p,
+		builder: newASTBuilder(p.columnTypes, p.functions),
+	}
+
+	if err := p.parser.ParseWithInterceptor(sql, ctx.transitionInterceptor); err != nil {
+		return nil, err
+	}
+
+	if ctx.openBraces > 0 {
+		return nil, &string_parser.ParseError{
+			Position: len(sql),
+			Eof:      true,
+			Expected: []string{closedBrace},
+			Err:      fmt.Errorf("EOF while searching for closing brace ')'"),
+		}
+	}
 
-	if err := p.parser.Parse(sql); err != nil {
+	return ctx.builder.build()
+}
+
+func (p *sqlParser) Render(node Node) (string, interface{}, error) {
+	renderer := astRenderer{
+		columnPrefix:     p.columnPrefix,
+		columnMapping:    p.columnMapping,
+		placeholderStyle: p.placeholderStyle,
+	}
+	if err := renderer.render(node); err != nil {
 		return "", nil, err
 	}
+	return renderer.qry.String(), renderer.result(), nil
+}
 
-	if p.openBraces > 0 {
-		return "", nil, fmt.Errorf("EOF while searching for closing brace ')'")
+func (p *sqlParser) Format(sql string) (string, error) {
+	tree, err := p.ParseToAST(sql)
+	if err != nil {
+		return "", err
 	}
 
-	p.resultQry = strings.Trim(p.resultQry, " ")
-	return p.resultQry, p.resultValues, nil
+	formatter := astFormatter{}
+	if err := formatter.formatOperand(tree, LogicalOr); err != nil {
+		return "", err
+	}
+	return formatter.sb.String(), nil
 }
 
-func (p *sqlParser) reset() {
-	p.complexity = 0
-	p.openBraces = 0
-	p.resultQry = ""
-	p.resultValues = nil
+func (p *sqlParser) Complete(prefix string) []string_parser.Suggestion {
+	return p.parser.Complete(prefix)
+}
+
+// suggest suggests the valid columns and the allowed functions, then calls the configured provider
+func (p *sqlParser) suggest(tokenName string, family string, partial string) []string {
+	var ret []string
+	switch family {
+	case columnTokenFamily:
+		ret = append(ret, p.validColumns...)
+	case functionTokenFamily:
+		for name := range p.functions {
+			ret = append(ret, name)
+		}
+		sort.Strings(ret)
+	}
+	if p.suggestionProvider != nil {
+		ret = append(ret, p.suggestionProvider(tokenName, family, partial)...)
+	}
+	return ret
 }
 
-func (p *sqlParser) transitionInterceptor(_, to *state_machine.State[string, string], tokenValue string) error {
+func (p *sqlParseContext) transitionInterceptor(_, to *state_machine.State[string, string], tokenValue string) error {
 	countOpenBraces := func(tok string) error {
 		switch tok {
 		case "(":
@@ -81,44 +179,24 @@ func (p *sqlParser) transitionInterceptor(_, to *state_machine.State[string, str
 		if err := countOpenBraces(tokenValue); err != nil {
 			return err
 		}
-		p.resultQry += tokenValue
-		return nil
-	case valueTokenFamily:
-		p.resultQry += " ?"
-		p.resultValues = append(p.resultValues, tokenValue)
-		return nil
-	case quotedValueTokenFamily:
-		p.resultQry += " ?"
-		// unescape
-		tmp := strings.ReplaceAll(tokenValue, `\'`, "'")
-		// remove quotes:
-		if len(tmp) > 1 {
-			tmp = string([]rune(tmp)[1 : len(tmp)-1])
-		}
-		p.resultValues = append(p.resultValues, tmp)
-		return nil
 	case logicalOpTokenFamily:
 		p.complexity++
-		if p.complexity > p.maximumComplexity {
-			return fmt.Errorf("maximum number of permitted joins (%d) exceeded", p.maximumComplexity)
+		if p.complexity > p.config.maximumComplexity {
+			return fmt.Errorf("maximum number of permitted joins (%d) exceeded", p.config.maximumComplexity)
 		}
-		p.resultQry += " " + tokenValue + " "
-		return nil
 	case columnTokenFamily:
 		// we want column names to be lowercase
 		columnName := strings.ToLower(tokenValue)
-		if len(p.validColumns) > 0 && !contains(p.validColumns, columnName) {
-			return fmt.Errorf("invalid column name: '%s', valid values are: %v", tokenValue, p.validColumns)
-		}
-		if p.columnPrefix != "" && !strings.HasPrefix(columnName, p.columnPrefix+".") {
-			columnName = p.columnPrefix + "." + columnName
+		if len(p.config.validColumns) > 0 && !contains(p.config.validColumns, columnName) {
+			return fmt.Errorf("invalid column name: '%s', valid values are: %v", tokenValue, p.config.validColumns)
 		}
-		p.resultQry += columnName
-		return nil
-	default:
-		p.resultQry += " " + tokenValue
-		return nil
 	}
+
+	if err := p.checkLimits(to.Name()); err != nil {
+		return err
+	}
+
+	return p.builder.onToken(to.Name(), tokenValue)
 }
 
 func contains(ary []string, value string) bool {
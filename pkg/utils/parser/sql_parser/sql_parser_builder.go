@@ -2,6 +2,7 @@ package sql_parser
 
 import (
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_parser"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_scanner"
 	"strings"
 )
 
@@ -25,6 +26,86 @@ func WithMaximumComplexity(maximumComplexity int) SQLParserOption {
 	}
 }
 
+// WithMaximumBraceDepth - the maximum nesting depth of the braces, including the braces of IN lists and function calls.
+// Exceeding it returns a MaximumBraceDepthError. 0 (the default) means no limit.
+func WithMaximumBraceDepth(maximumBraceDepth int) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.maximumBraceDepth = maximumBraceDepth
+	}
+}
+
+// WithMaximumInListLength - the maximum number of values of each IN list. Exceeding it returns a MaximumInListLengthError.
+// 0 (the default) means no limit.
+func WithMaximumInListLength(maximumInListLength int) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.maximumInListLength = maximumInListLength
+	}
+}
+
+// WithMaximumTokens - the maximum number of tokens (columns, operators, values, braces, ...) of the query.
+// Exceeding it returns a MaximumTokensError. 0 (the default) means no limit.
+func WithMaximumTokens(maximumTokens int) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.maximumTokens = maximumTokens
+	}
+}
+
+// WithMaximumInputLength - the maximum length (in bytes) of the query. Exceeding it returns a MaximumInputLengthError.
+// 0 (the default) means no limit.
+func WithMaximumInputLength(maximumInputLength int) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.maximumInputLength = maximumInputLength
+	}
+}
+
+// WithColumnMapping - maps the column names used in the query to the SQL expressions to be used in the rendered query
+// (ie: `region_id` -> `regions.id`). The column names in the query are still validated against WithValidColumns and
+// the mapped expressions are not prefixed with the column prefix.
+func WithColumnMapping(columnMapping map[string]string) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.columnMapping = make(map[string]string, len(columnMapping))
+		for name, expression := range columnMapping {
+			parser.columnMapping[strings.ToLower(name)] = expression
+		}
+	}
+}
+
+// WithColumnTypes - configures the type of the columns. Values compared with typed columns are validated and
+// coerced to the matching Go type. Columns not present in the map are not validated.
+func WithColumnTypes(columnTypes map[string]ColumnType) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.columnTypes = make(columnTypeRegistry, len(columnTypes))
+		for name, columnType := range columnTypes {
+			parser.columnTypes[strings.ToLower(name)] = columnType
+		}
+	}
+}
+
+// WithAllowedFunctions - configures the functions that can be called on the left side of a comparison, ie: `lower(name) = 'value'`
+func WithAllowedFunctions(functions ...SQLFunction) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.functions = make(map[string]SQLFunction, len(functions))
+		for _, f := range functions {
+			f.Name = strings.ToLower(f.Name)
+			parser.functions[f.Name] = f
+		}
+	}
+}
+
+func WithPlaceholderStyle(placeholderStyle PlaceholderStyle) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.placeholderStyle = placeholderStyle
+	}
+}
+
+// WithSuggestionProvider - configures a provider of the values suggested by Complete, ie: the values of the enum columns or
+// the known owners. The tokens whose values can be suggested belong to the VALUE and QUOTED families.
+func WithSuggestionProvider(suggestionProvider string_parser.SuggestionProvider) SQLParserOption {
+	return func(parser *sqlParser) {
+		parser.suggestionProvider = suggestionProvider
+	}
+}
+
 func NewSQLParser(options ...SQLParserOption) SQLParser {
 	parser := &sqlParser{
 		maximumComplexity: defaultMaximumComplexity,
@@ -34,10 +115,20 @@ func NewSQLParser(options ...SQLParserOption) SQLParser {
 		option(parser)
 	}
 
+	var functionNames []string
+	for name := range parser.functions {
+		functionNames = append(functionNames, name)
+	}
+
+	// the scanning stops one token after the limit: that token is refused by checkLimits with a MaximumTokensError
+	scannedTokens := -1
+	if parser.maximumTokens > 0 {
+		scannedTokens = parser.maximumTokens + 1
+	}
 	stringParser := string_parser.NewStringParserBuilder().
-		WithGrammar(BasicSQLGrammar()).
-		WithTransitionInterceptor(parser.transitionInterceptor).
-		WithScanner(NewSQLScanner()).
+		WithGrammar(BasicSQLGrammar(functionNames...)).
+		WithScannerFactory(func() string_scanner.Scanner { return newLimitedSQLScanner(scannedTokens) }).
+		WithSuggestionProvider(parser.suggest).
 		Build()
 
 	parser.parser = stringParser
@@ -0,0 +1,91 @@
+package sql_parser
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Concurrency", func() {
+	const goroutines = 20
+	const iterations = 50
+
+	It("A single SQLParser can be used by multiple goroutines", func() {
+		parser := NewSQLParser(WithValidColumns("name", "size", "owner"), WithColumnPrefix("main"))
+
+		var wg sync.WaitGroup
+		errs := make(chan error, goroutines*iterations)
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				for i := 0; i < iterations; i++ {
+					qry, values, err := parser.Parse(fmt.Sprintf("(name = 'n%d' or size > %d) and owner in ('a', 'b')", g, i))
+					if err != nil {
+						errs <- err
+						continue
+					}
+					Expect(qry).To(Equal("(main.name = ? or main.size > ?) and main.owner in( ? , ?)"))
+					Expect(values).To(Equal([]interface{}{fmt.Sprintf("n%d", g), fmt.Sprint(i), "a", "b"}))
+
+					// errors must not leak into other goroutines' parsing
+					_, _, err = parser.Parse("(unknown = 1")
+					Expect(err).To(HaveOccurred())
+				}
+			}(g)
+		}
+		wg.Wait()
+		close(errs)
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("A single OrderByParser can be used by multiple goroutines", func() {
+		parser := NewOrderByParser(WithColumnPrefix("main"))
+
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				for i := 0; i < iterations; i++ {
+					orderBy, err := parser.Parse(fmt.Sprintf("c%d desc, name", g))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(orderBy).To(Equal(fmt.Sprintf("main.c%d DESC, main.name ASC", g)))
+				}
+			}(g)
+		}
+		wg.Wait()
+	})
+})
+
+const benchmarkQuery = "((cloud_provider = Value and name = value1) and (owner <> value2 or region=b ) or owner in ('owner1', 'owner2', 'owner3')) or owner=c or name=e and region LIKE '%test%'"
+
+// BenchmarkSharedParser parses using a single parser: the grammar is compiled only once
+func BenchmarkSharedParser(b *testing.B) {
+	parser := NewSQLParser()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, _, err := parser.Parse(benchmarkQuery); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkParserPerRequest builds a new parser for each parsed string
+func BenchmarkParserPerRequest(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, _, err := NewSQLParser().Parse(benchmarkQuery); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -1,15 +1,30 @@
 package sql_parser
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_parser"
 )
 
 var _ = Describe("SQLParser", func() {
+	columnTypes := map[string]ColumnType{
+		"name":               StringColumn(),
+		"SIZE":               IntColumn(),
+		"enabled":            BoolColumn(),
+		"creation_timestamp": TimestampColumn(),
+		"state":              EnumColumn("ready", "installing", "error"),
+		"labels":             JSONBColumn(),
+		"properties":         JSONBColumn(WithJSONBPaths("metadata.labels.*", "spec"), WithJSONBMaximumDepth(3)),
+	}
+
 	type testData struct {
 		qry        string
 		outQry     string
-		outValues  []interface{}
+		outValues  interface{}
 		wantErr    bool
 		errMessage string
 	}
@@ -138,6 +153,49 @@ var _ = Describe("SQLParser", func() {
 		}, NewSQLParser()),
 	)
 
+	DescribeTable("IS NULL and BETWEEN Parsing", parserTest,
+		Entry("IS NULL", testData{
+			qry:       "name IS NULL",
+			outQry:    "name IS NULL",
+			outValues: nil,
+			wantErr:   false,
+		}, NewSQLParser()),
+		Entry("IS NOT NULL in complex query", testData{
+			qry:       "(name is not null or owner = 'me') and region IS NULL",
+			outQry:    "(name is not null or owner = ?) and region IS NULL",
+			outValues: []interface{}{"me"},
+			wantErr:   false,
+		}, NewSQLParser()),
+		Entry("IS without NULL", testData{
+			qry:        "name IS 'test'",
+			wantErr:    true,
+			errMessage: "[9] error parsing the filter: unexpected token `'test'`",
+		}, NewSQLParser()),
+		Entry("BETWEEN", testData{
+			qry:       "expiration_timestamp BETWEEN '2024-01-01' AND '2024-02-01'",
+			outQry:    "expiration_timestamp BETWEEN ? AND ?",
+			outValues: []interface{}{"2024-01-01", "2024-02-01"},
+			wantErr:   false,
+		}, NewSQLParser()),
+		Entry("NOT BETWEEN joined with other conditions", testData{
+			qry:       "size not between 1 and 10 and name = 'test' or JSONB->'spec'->>'size' between 3 and '5'",
+			outQry:    "size not between ? and ? and name = ? or jsonb -> 'spec' ->> 'size' between ? and ?",
+			outValues: []interface{}{"1", "10", "test", "3", "5"},
+			wantErr:   false,
+		}, NewSQLParser()),
+		Entry("BETWEEN without AND", testData{
+			qry:        "size BETWEEN 1 OR 10",
+			wantErr:    true,
+			errMessage: "[16] error parsing the filter: unexpected token `OR`",
+		}, NewSQLParser()),
+		Entry("BETWEEN AND is not counted as join", testData{
+			qry:       "size BETWEEN 1 AND 10 and creation_timestamp BETWEEN '2024-01-01' AND '2024-02-01'",
+			outQry:    "size BETWEEN ? AND ? and creation_timestamp BETWEEN ? AND ?",
+			outValues: []interface{}{"1", "10", "2024-01-01", "2024-02-01"},
+			wantErr:   false,
+		}, NewSQLParser(WithMaximumComplexity(1))),
+	)
+
 	DescribeTable("BRACES validation", parserTest,
 		Entry("Complex query with braces", testData{
 			qry:       "((cloud_provider = Value and name = value1) and (owner <> value2 or region=b ) ) or owner=c or name=e and region LIKE '%test%'",
@@ -236,6 +294,35 @@ var _ = Describe("SQLParser", func() {
 		}, NewSQLParser(WithMaximumComplexity(3))),
 	)
 
+	DescribeTable("LIMITS", func(qry string, parser SQLParser, expected error, errMessage string) {
+		_, _, err := parser.Parse(qry)
+		if expected == nil {
+			Expect(err).ToNot(HaveOccurred())
+			return
+		}
+		Expect(err).To(MatchError(errMessage))
+		Expect(errors.Unwrap(err)).To(BeAssignableToTypeOf(expected))
+	},
+		Entry("brace depth ok", "((name = a) or (owner in (b)))", NewSQLParser(WithMaximumBraceDepth(3)), nil, ""),
+		Entry("brace depth exceeded", "(((name = a)))", NewSQLParser(WithMaximumBraceDepth(2)),
+			&MaximumBraceDepthError{}, "[3] error parsing the filter: maximum brace depth (2) exceeded"),
+		Entry("IN list braces are counted", "(owner in (a))", NewSQLParser(WithMaximumBraceDepth(1)),
+			&MaximumBraceDepthError{}, "[11] error parsing the filter: maximum brace depth (1) exceeded"),
+		Entry("IN list length ok", "owner in (a, b, c) and name in (d, e, f)", NewSQLParser(WithMaximumInListLength(3)), nil, ""),
+		Entry("IN list length exceeded", "owner in (a, b) or name in (c, d, e)", NewSQLParser(WithMaximumInListLength(2)),
+			&MaximumInListLengthError{}, "[35] error parsing the filter: maximum number of values in an IN list (2) exceeded"),
+		Entry("tokens ok", "name = a and owner = b", NewSQLParser(WithMaximumTokens(7)), nil, ""),
+		Entry("tokens exceeded", "name = a and owner = b", NewSQLParser(WithMaximumTokens(6)),
+			&MaximumTokensError{}, "[22] error parsing the filter: maximum number of tokens (6) exceeded"),
+		Entry("input length ok", "name = 'abc'", NewSQLParser(WithMaximumInputLength(12)), nil, ""),
+		Entry("input length exceeded", "name = 'abcd'", NewSQLParser(WithMaximumInputLength(12)),
+			&MaximumInputLengthError{}, "[1] error parsing the filter: maximum input length (12) exceeded: 13"),
+		Entry("input length exceeded by text producing no tokens", strings.Repeat(" ", 1<<20), NewSQLParser(WithMaximumInputLength(1024)),
+			&MaximumInputLengthError{}, "[1] error parsing the filter: maximum input length (1024) exceeded: 1048576"),
+		Entry("tokens exceeded by a long query", strings.Repeat("name = a and ", 100000)+"name = a", NewSQLParser(WithMaximumTokens(10)),
+			&MaximumTokensError{}, "[34] error parsing the filter: maximum number of tokens (10) exceeded"),
+	)
+
 	DescribeTable("ALLOWED COLUMNS", parserTest,
 		Entry("Any Column", testData{
 			qry:       "((cloud_provider = Value and name = value1) and (owner <> value2 or region=b ) ) or owner=c or name=e and region LIKE '%test%'",
@@ -258,6 +345,189 @@ var _ = Describe("SQLParser", func() {
 		}, NewSQLParser(WithValidColumns("cloud_provider", "name", "region"))),
 	)
 
+	DescribeTable("PLACEHOLDER STYLE", parserTest,
+		Entry("Question mark placeholders", testData{
+			qry:       "name = 'mickey' and surname IN ('mouse', 'duck')",
+			outQry:    "name = ? and surname IN( ? , ?)",
+			outValues: []interface{}{"mickey", "mouse", "duck"},
+			wantErr:   false,
+		}, NewSQLParser(WithPlaceholderStyle(QuestionMarkPlaceholder))),
+		Entry("Dollar placeholders", testData{
+			qry:       `(name = 'mickey' or surname not in ('mouse', 'duck')) and age BETWEEN 1 AND 99 and manifest -> 'data' @> '{"a":1}'`,
+			outQry:    `(name = $1 or surname not  in( $2 , $3)) and age BETWEEN $4 AND $5 and manifest -> 'data' @> $6`,
+			outValues: []interface{}{"mickey", "mouse", "duck", "1", "99", `{"a":1}`},
+			wantErr:   false,
+		}, NewSQLParser(WithPlaceholderStyle(DollarPlaceholder))),
+		Entry("Named placeholders", testData{
+			qry:    `name = 'mickey' and surname IN ('mouse', 'duck') or manifest -> 'data' @> '{"a":1}'`,
+			outQry: `main.name = :p1 and main.surname IN( :p2 , :p3) or main.manifest -> 'data' @> :p4`,
+			outValues: map[string]interface{}{
+				"p1": "mickey", "p2": "mouse", "p3": "duck", "p4": `{"a":1}`,
+			},
+			wantErr: false,
+		}, NewSQLParser(WithPlaceholderStyle(NamedPlaceholder), WithColumnPrefix("main"))),
+	)
+
+	DescribeTable("COLUMN TYPES", parserTest,
+		Entry("Values are coerced", testData{
+			qry:    "size > 10 and enabled = true and state in (ready, 'installing') and creation_timestamp BETWEEN '2024-01-01' AND '2024-02-01T10:00:00Z' and name like '%test%'",
+			outQry: "size > ? and enabled = ? and state in( ? , ?) and creation_timestamp BETWEEN ? AND ? and name like ?",
+			outValues: []interface{}{
+				int64(10), true, "ready", "installing",
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC),
+				"%test%",
+			},
+			wantErr: false,
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Untyped columns are not validated", testData{
+			qry:       "owner = 'abc'",
+			outQry:    "owner = ?",
+			outValues: []interface{}{"abc"},
+			wantErr:   false,
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("JSONB text values are strings", testData{
+			qry:       "labels -> 'a' ->> 'b' like '%x%' and labels is not null",
+			outQry:    "labels -> 'a' ->> 'b' like ? and labels is not null",
+			outValues: []interface{}{"%x%"},
+			wantErr:   false,
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Invalid int", testData{
+			qry:        "size > 'abc'",
+			wantErr:    true,
+			errMessage: "[8] error parsing the filter: invalid value 'abc' for column 'size' of type int",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Invalid int in list", testData{
+			qry:        "size in (1, 2, x)",
+			wantErr:    true,
+			errMessage: "[16] error parsing the filter: invalid value x for column 'size' of type int",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Invalid timestamp", testData{
+			qry:        "creation_timestamp between '2024-01-01' and yesterday",
+			wantErr:    true,
+			errMessage: "[45] error parsing the filter: invalid value yesterday for column 'creation_timestamp' of type timestamp",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Invalid enum", testData{
+			qry:        "state = 'unknown'",
+			wantErr:    true,
+			errMessage: "[9] error parsing the filter: invalid value 'unknown' for column 'state' of type enum, valid values are: [ready installing error]",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("LIKE on int", testData{
+			qry:        "size LIKE '1%'",
+			wantErr:    true,
+			errMessage: "[6] error parsing the filter: operator 'LIKE' is not supported for column 'size' of type int",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("BETWEEN on bool", testData{
+			qry:        "enabled not between false and true",
+			wantErr:    true,
+			errMessage: "[13] error parsing the filter: operator 'BETWEEN' is not supported for column 'enabled' of type bool",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("JSONB operator on a non JSONB column", testData{
+			qry:        "name -> 'a' ->> 'b' = 'c'",
+			wantErr:    true,
+			errMessage: "[6] error parsing the filter: operator '->' is not supported for column 'name' of type string",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Allowed JSONB paths", testData{
+			qry:       `properties -> 'metadata' -> 'labels' ->> 'env' = 'prod' and properties -> 'spec' @> '{"size": 3}'`,
+			outQry:    "properties -> 'metadata' -> 'labels' ->> 'env' = ? and properties -> 'spec' @> ?",
+			outValues: []interface{}{"prod", `{"size": 3}`},
+			wantErr:   false,
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("JSONB keys are rendered as SQL string literals", testData{
+			qry:       `properties -> 'metadata' -> 'labels' ->> 'a\' OR 1=1 --' = 'x'`,
+			outQry:    "properties -> 'metadata' -> 'labels' ->> 'a'' OR 1=1 --' = ?",
+			outValues: []interface{}{"x"},
+			wantErr:   false,
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Backslashes in JSONB keys are not escape characters", testData{
+			qry:       `properties -> 'metadata' -> 'labels' ->> 'a\b' = 'x'`,
+			outQry:    `properties -> 'metadata' -> 'labels' ->> 'a\b' = ?`,
+			outValues: []interface{}{"x"},
+			wantErr:   false,
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("JSONB path not allowed", testData{
+			qry:        "properties -> 'metadata' ->> 'name' = 'x'",
+			wantErr:    true,
+			errMessage: "[37] error parsing the filter: JSONB path 'metadata.name' is not allowed for column 'properties', valid values are: [metadata.labels.* spec]",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("JSONB path prefixes are not allowed", testData{
+			qry:        "properties -> 'metadata' @> '{}'",
+			wantErr:    true,
+			errMessage: "[26] error parsing the filter: JSONB path 'metadata' is not allowed for column 'properties', valid values are: [metadata.labels.* spec]",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("JSONB maximum depth", testData{
+			qry:        "properties -> 'metadata' -> 'labels' -> 'env' ->> 'x' is null",
+			wantErr:    true,
+			errMessage: "[51] error parsing the filter: maximum JSONB path depth (3) exceeded for column 'properties'",
+		}, NewSQLParser(WithColumnTypes(columnTypes))),
+		Entry("Invalid JSON", testData{
+			qry:        "labels -> 'a' @> '{invalid'",
+			wantErr:    true,
+			errMessage: "[18] error parsing the filter: invalid JSON value '{invalid' for operator '@>'",
+		}, NewSQLParser()),
+	)
+
+	DescribeTable("COLUMN MAPPING", parserTest,
+		Entry("Mapped columns", testData{
+			qry:       "cluster.name = 'test' and Region_ID in ('us-east-1', 'eu-west-1') or labels->'a'->>'b' = 'c' or owner = 'me'",
+			outQry:    "main.name = ? and regions.id in( ? , ?) or main.properties -> 'labels' -> 'a' ->> 'b' = ? or main.owner = ?",
+			outValues: []interface{}{"test", "us-east-1", "eu-west-1", "c", "me"},
+			wantErr:   false,
+		}, NewSQLParser(
+			WithColumnPrefix("main"),
+			WithColumnMapping(map[string]string{
+				"cluster.name": "main.name",
+				"region_id":    "regions.id",
+				"labels":       "main.properties -> 'labels'",
+			}),
+		)),
+		Entry("Mapped columns are validated using the query names", testData{
+			qry:        "cluster.name = 'test' and regions.id = 'us-east-1'",
+			wantErr:    true,
+			errMessage: "[27] error parsing the filter: invalid column name: 'regions.id', valid values are: [cluster.name region_id]",
+		}, NewSQLParser(
+			WithValidColumns("cluster.name", "region_id"),
+			WithColumnMapping(map[string]string{
+				"cluster.name": "clusters.name",
+				"region_id":    "regions.id",
+			}),
+		)),
+	)
+
+	DescribeTable("FUNCTIONS", parserTest,
+		Entry("Function on the left side", testData{
+			qry:       "LOWER(name) = 'foo' and date_trunc('day', creation_timestamp) BETWEEN '2024-01-01' AND '2024-02-01'",
+			outQry:    "lower(main.name) = ? and date_trunc(?, main.creation_timestamp) BETWEEN ? AND ?",
+			outValues: []interface{}{"foo", "day", "2024-01-01", "2024-02-01"},
+			wantErr:   false,
+		}, NewSQLParser(
+			WithColumnPrefix("main"),
+			WithAllowedFunctions(Function("lower", 1), Function("date_trunc", 2)),
+		)),
+		Entry("Function with variable arguments", testData{
+			qry:       "coalesce(name, owner, 'none') in ('a', 'b') or now() > '2024-01-01'",
+			outQry:    "coalesce(name, owner, ?) in( ? , ?) or now() > ?",
+			outValues: []interface{}{"none", "a", "b", "2024-01-01"},
+			wantErr:   false,
+		}, NewSQLParser(
+			WithAllowedFunctions(SQLFunction{Name: "coalesce", MinArgs: 1, MaxArgs: -1}, Function("now", 0)),
+		)),
+		Entry("Wrong number of arguments", testData{
+			qry:        "lower(name, owner) = 'foo'",
+			wantErr:    true,
+			errMessage: "[18] error parsing the filter: function 'lower' expects 1 argument(s), got 2",
+		}, NewSQLParser(WithAllowedFunctions(Function("lower", 1)))),
+		Entry("Function not allowed", testData{
+			qry:        "upper(name) = 'foo'",
+			wantErr:    true,
+			errMessage: "[6] error parsing the filter: unexpected token `(`",
+		}, NewSQLParser(WithAllowedFunctions(Function("lower", 1)))),
+		Entry("Function arguments are validated against the valid columns", testData{
+			qry:        "lower(surname) = 'foo'",
+			wantErr:    true,
+			errMessage: "[7] error parsing the filter: invalid column name: 'surname', valid values are: [name]",
+		}, NewSQLParser(WithValidColumns("name"), WithAllowedFunctions(Function("lower", 1)))),
+	)
+
 	DescribeTable("COLUMN PREFIX", parserTest,
 		Entry("Empty prefix", testData{
 			qry:       "((cloud_provider = Value and name = value1) and (owner <> value2 or region=b ) ) or owner=c or name=e and region LIKE '%test%'",
@@ -278,4 +548,74 @@ var _ = Describe("SQLParser", func() {
 			wantErr:   false,
 		}, NewSQLParser(WithColumnPrefix("main"))),
 	)
+
+	It("Uses valid grammars", func() {
+		grammar := BasicSQLGrammar("lower", "upper")
+		Expect(grammar.Validate()).To(Succeed())
+		grammar = OrderByGrammar()
+		Expect(grammar.Validate()).To(Succeed())
+	})
+
+	DescribeTable("Parse errors", func(qry string, expected string_parser.ParseError) {
+		_, _, err := NewSQLParser().Parse(qry)
+		var parseError *string_parser.ParseError
+		Expect(errors.As(err, &parseError)).To(BeTrue())
+		Expect(parseError.Position).To(Equal(expected.Position))
+		Expect(parseError.Token).To(Equal(expected.Token))
+		Expect(parseError.Eof).To(Equal(expected.Eof))
+		Expect(parseError.Expected).To(Equal(expected.Expected))
+	},
+		Entry("unexpected token", "name = 'a' and =", string_parser.ParseError{
+			Position: 15, Token: "=", Expected: []string{function, column, openBrace},
+		}),
+		Entry("unexpected operator", "name ! 'a'", string_parser.ParseError{
+			Position: 5, Token: "!", Expected: []string{gt, lt, gte, lte, eq, notEq, like, ilike, in, not, is, between, jsonbArrow},
+		}),
+		Entry("refused by the interceptor", "(name = 'a'))", string_parser.ParseError{
+			Position: 12, Token: ")",
+		}),
+		Entry("EOF", "name IS NOT", string_parser.ParseError{
+			Position: 11, Eof: true, Expected: []string{null},
+		}),
+		Entry("EOF with open braces", "(name = 'a'", string_parser.ParseError{
+			Position: 11, Eof: true, Expected: []string{closedBrace},
+		}),
+	)
+
+	DescribeTable("Completion", func(prefix string, expectedValues []string, expectedPosition int) {
+		parser := NewSQLParser(
+			WithValidColumns("name", "owner", "region"),
+			WithAllowedFunctions(Function("lower", 1)),
+			WithSuggestionProvider(func(tokenName string, family string, partial string) []string {
+				if tokenName == quotedValue {
+					return []string{"'eu-west-1'", "'us-east-1'"}
+				}
+				return nil
+			}),
+		)
+		suggestions := parser.Complete(prefix)
+		values := make([]string, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			if suggestion.Placeholder {
+				// free values are shown as placeholders
+				Expect(suggestion.Value).To(BeEmpty())
+				values = append(values, "<"+suggestion.TokenName+">")
+			} else {
+				values = append(values, suggestion.Value)
+			}
+			Expect(suggestion.Position).To(Equal(expectedPosition))
+		}
+		Expect(values).To(Equal(expectedValues))
+	},
+		Entry("empty prefix", "", []string{"lower", "name", "owner", "region", "("}, 0),
+		Entry("partial column", "na", []string{"name"}, 0),
+		Entry("partial operator", "name i", []string{"ILIKE", "IN", "IS"}, 5),
+		Entry("case insensitive", "name = 'a' o", []string{"OR"}, 11),
+		Entry("values from the provider", "region = 'e", []string{"'eu-west-1'"}, 9),
+		Entry("free values", "name = ", []string{"'eu-west-1'", "'us-east-1'", "<VALUE>"}, 7),
+		Entry("in list", "name in ('a' ", []string{",", ")"}, 13),
+		Entry("unknown column", "x", []string{}, 0),
+		Entry("multi-byte last character", "name = à", []string{"<VALUE>"}, 7),
+		Entry("invalid prefix", "name name", []string{}, 0),
+	)
 })
@@ -13,11 +13,24 @@ const (
 	NO_TOKEN
 )
 
+// sqlLexer - the rules splitting SQL strings by whole words or sentences if it finds quotes. Operators (`=`, `<>`, `->>`, `@>`...)
+// are split from the words even if they are not surrounded by spaces.
+var sqlLexer = string_scanner.NewLexerBuilder().
+	WithSkipRule(` +`).
+	WithQuoteRule(QUOTED_LITERAL, '\'', '\\').
+	WithLiteralRule(LITERAL, ",").
+	WithLiteralRule(BRACE, "(", ")").
+	WithRegexpRule(OP, `[@=<>-]+`).
+	WithRegexpRule(LITERAL, `[^ ,()'@=<>-]+`).
+	Build()
+
 // scanner - This scanner is to be used to parse SQL Strings. It splits the provided string by whole words
 // or sentences if it finds quotes. Nested round braces are supported too.
 type scanner struct {
 	tokens []string_scanner.Token
 	pos    int
+	// maximumTokens - the number of tokens after which the scanning stops, < 0 means no limit
+	maximumTokens int
 }
 
 var _ string_scanner.Scanner = &scanner{}
@@ -25,78 +38,7 @@ var _ string_scanner.Scanner = &scanner{}
 // Init feeds the scanner with the text to be scanned
 func (s *scanner) Init(txt string) {
 	s.pos = -1
-	s.tokens = nil
-
-	var tokens []string_scanner.Token
-	currentTokenType := NO_TOKEN
-
-	quoted := false
-	escaped := false
-
-	sendCurrentTokens := func() {
-		res := ""
-		for _, token := range tokens {
-			res += token.Value
-		}
-		if res != "" {
-			s.tokens = append(s.tokens, string_scanner.Token{TokenType: currentTokenType, Value: res, Position: tokens[0].Position})
-		}
-		tokens = nil
-		currentTokenType = NO_TOKEN
-	}
-
-	// extract all the tokens from the string
-	for i, currentChar := range txt {
-		switch {
-		case currentChar == '\'' && quoted:
-			tokens = append(tokens, string_scanner.Token{TokenType: QUOTED_LITERAL, Value: "'", Position: i})
-			if !escaped {
-				sendCurrentTokens()
-				quoted = false
-				currentTokenType = NO_TOKEN
-			}
-			escaped = false
-		case currentChar == '\\' && quoted:
-			escaped = true
-			tokens = append(tokens, string_scanner.Token{TokenType: QUOTED_LITERAL, Value: "\\", Position: i})
-		case quoted: // everything that is not "'" or '\' must be added to the current token if quoted is true
-			tokens = append(tokens, string_scanner.Token{TokenType: LITERAL, Value: string(currentChar), Position: i})
-		case currentChar == ' ':
-			sendCurrentTokens()
-		case currentChar == ',':
-			sendCurrentTokens()
-			s.tokens = append(s.tokens, string_scanner.Token{TokenType: LITERAL, Value: string(currentChar), Position: i})
-		case currentChar == '\'':
-			sendCurrentTokens()
-			quoted = true
-			currentTokenType = QUOTED_LITERAL
-			tokens = append(tokens, string_scanner.Token{TokenType: OP, Value: "'", Position: i})
-		case currentChar == '\\':
-			if currentTokenType != NO_TOKEN && currentTokenType != LITERAL && currentTokenType != QUOTED_LITERAL {
-				sendCurrentTokens()
-			}
-			currentTokenType = LITERAL
-			tokens = append(tokens, string_scanner.Token{TokenType: LITERAL, Value: `\`, Position: i})
-		case currentChar == '@', currentChar == '-', currentChar == '=', currentChar == '<', currentChar == '>':
-			// found op Token
-			if currentTokenType != NO_TOKEN && currentTokenType != OP {
-				sendCurrentTokens()
-			}
-			tokens = append(tokens, string_scanner.Token{TokenType: OP, Value: string(currentChar), Position: i})
-			currentTokenType = OP
-		case currentChar == '(', currentChar == ')':
-			sendCurrentTokens()
-			s.tokens = append(s.tokens, string_scanner.Token{TokenType: BRACE, Value: string(currentChar), Position: i})
-		default:
-			if currentTokenType != NO_TOKEN && currentTokenType != LITERAL && currentTokenType != QUOTED_LITERAL {
-				sendCurrentTokens()
-			}
-			currentTokenType = LITERAL
-			tokens = append(tokens, string_scanner.Token{TokenType: LITERAL, Value: string(currentChar), Position: i})
-		}
-	}
-
-	sendCurrentTokens()
+	s.tokens = sqlLexer.TokenizeN(txt, s.maximumTokens)
 }
 
 // Next moves to the next token and return `true` if another token is present. Otherwise returns `false`
@@ -127,7 +69,13 @@ func (s *scanner) Token() *string_scanner.Token {
 }
 
 func NewSQLScanner() string_scanner.Scanner {
+	return newLimitedSQLScanner(-1)
+}
+
+// newLimitedSQLScanner returns a scanner that stops scanning after maximumTokens tokens (< 0 means no limit)
+func newLimitedSQLScanner(maximumTokens int) string_scanner.Scanner {
 	return &scanner{
-		pos: -1,
+		pos:           -1,
+		maximumTokens: maximumTokens,
 	}
 }
@@ -102,6 +102,26 @@ var _ = Describe("SQL String Scanner", func() {
 				makeToken(LITERAL, "3", 61),
 			},
 		),
+		Entry("Backslash not followed by a quote",
+			`name = 'a\b' and name = 'c'`,
+			[]string_scanner.Token{
+				makeToken(LITERAL, "name", 0),
+				makeToken(OP, "=", 5),
+				makeToken(QUOTED_LITERAL, `'a\b'`, 7),
+				makeToken(LITERAL, "and", 13),
+				makeToken(LITERAL, "name", 17),
+				makeToken(OP, "=", 22),
+				makeToken(QUOTED_LITERAL, `'c'`, 24),
+			},
+		),
+		Entry("Unterminated quote",
+			`name = 'a b`,
+			[]string_scanner.Token{
+				makeToken(LITERAL, "name", 0),
+				makeToken(OP, "=", 5),
+				makeToken(QUOTED_LITERAL, `'a b`, 7),
+			},
+		),
 		Entry("SQL with empty parenthesis",
 			"name IN ()",
 			[]string_scanner.Token{
@@ -0,0 +1,207 @@
+package state_machine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolutionMode - how the next state is chosen when more than one of the next states accepts a value
+type ResolutionMode int
+
+const (
+	// FirstMatch - the first next state accepting the value wins, even if it leads to a dead end (like Move). This is the default.
+	FirstMatch ResolutionMode = iota
+	// Backtracking - all the next states accepting the value are explored: the first path (in transitions order) that
+	// accepts all the values wins
+	Backtracking
+	// StrictBacktracking - like Backtracking, but more than one path accepting all the values is reported as an AmbiguityError
+	StrictBacktracking
+)
+
+// NoPathError - returned by FindPath when no path accepts all the values
+type NoPathError[U any] struct {
+	// Index - the index of the first value that has been refused by all the explored paths. When Eof is true, this is the
+	// number of values
+	Index int
+	// Value - the refused value. Zero value when Eof is true
+	Value U
+	// Eof - true if some paths accepted all the values but none of them ended in a state that can be the last one
+	Eof bool
+	// Expected - the names of the states that would have been accepted at Index
+	Expected []string
+}
+
+func (e *NoPathError[U]) Error() string {
+	if e.Eof {
+		return "EOF encountered before reaching a final state"
+	}
+	return fmt.Sprintf("unexpected token `%v`", e.Value)
+}
+
+// AmbiguityError - returned by FindPath in StrictBacktracking mode when more than one path accepts all the values
+type AmbiguityError struct {
+	// Index - the index of the first value accepted by different states in the reported paths
+	Index int
+	// Paths - the names of the states of two of the paths accepting the values
+	Paths [][]string
+}
+
+func (e *AmbiguityError) Error() string {
+	paths := make([]string, 0, len(e.Paths))
+	for _, path := range e.Paths {
+		paths = append(paths, "["+strings.Join(path, " ")+"]")
+	}
+	return fmt.Sprintf("ambiguous input: more than one path accepts all the values: %s", strings.Join(paths, ", "))
+}
+
+type pathKey[T any, U any] struct {
+	state *State[T, U]
+	index int
+}
+
+// pathFinder - explores all the paths starting from a state. The number of complete paths starting from each state
+// and index is memoized, so that each state is evaluated at most once for each value.
+type pathFinder[T any, U any] struct {
+	values []U
+	// counts the paths accepting all the remaining values (up to 2: we only need to know if there is more than one)
+	counts map[pathKey[T, U]]int
+
+	// the furthest index reached and the states reached there
+	furthest       int
+	furthestStates []*State[T, U]
+}
+
+// FindPath - returns the states to move to in order to accept all the values, ending in a state that can be the last one.
+// Interceptors and observers are not called: use MoveTo to follow the returned path. Returns a *NoPathError if no path
+// accepts all the values and, in StrictBacktracking mode, an *AmbiguityError if more than one path does.
+// In FirstMatch mode, the path is the one followed by Move.
+func (s *State[T, U]) FindPath(values []U, mode ResolutionMode) ([]*State[T, U], error) {
+	f := &pathFinder[T, U]{values: values, counts: map[pathKey[T, U]]int{}, furthest: -1}
+
+	if mode == FirstMatch {
+		return f.firstMatch(s)
+	}
+
+	if f.count(s, 0) == 0 {
+		return nil, f.noPathError()
+	}
+
+	paths := f.paths(s, 0, nil, 2)
+	if mode == StrictBacktracking && len(paths) > 1 {
+		return nil, newAmbiguityError(paths)
+	}
+	return paths[0], nil
+}
+
+func (f *pathFinder[T, U]) firstMatch(start *State[T, U]) ([]*State[T, U], error) {
+	var path []*State[T, U]
+	state := start
+	for i, value := range f.values {
+		var next *State[T, U]
+		for _, n := range state.next {
+			if n.accept(value) {
+				next = n
+				break
+			}
+		}
+		if next == nil {
+			return nil, &NoPathError[U]{Index: i, Value: value, Expected: state.ValidTransitions()}
+		}
+		path = append(path, next)
+		state = next
+	}
+	if !state.last {
+		return nil, &NoPathError[U]{Index: len(f.values), Eof: true, Expected: state.ValidTransitions()}
+	}
+	return path, nil
+}
+
+// count returns the number of paths (up to 2) that, starting from the state, accept the values from index
+func (f *pathFinder[T, U]) count(state *State[T, U], index int) int {
+	key := pathKey[T, U]{state: state, index: index}
+	if c, ok := f.counts[key]; ok {
+		return c
+	}
+
+	if index > f.furthest {
+		f.furthest = index
+		f.furthestStates = nil
+	}
+	if index == f.furthest {
+		f.furthestStates = append(f.furthestStates, state)
+	}
+
+	c := 0
+	if index == len(f.values) {
+		if state.last {
+			c = 1
+		}
+	} else {
+		for _, next := range state.next {
+			if next.accept(f.values[index]) {
+				c += f.count(next, index+1)
+				if c > 1 {
+					c = 2
+					break
+				}
+			}
+		}
+	}
+
+	f.counts[key] = c
+	return c
+}
+
+// paths returns up to limit complete paths starting from the state. To be called after count.
+func (f *pathFinder[T, U]) paths(state *State[T, U], index int, prefix []*State[T, U], limit int) [][]*State[T, U] {
+	if index == len(f.values) {
+		return [][]*State[T, U]{append([]*State[T, U]{}, prefix...)}
+	}
+
+	var ret [][]*State[T, U]
+	for _, next := range state.next {
+		if len(ret) >= limit {
+			break
+		}
+		if next.accept(f.values[index]) && f.count(next, index+1) > 0 {
+			ret = append(ret, f.paths(next, index+1, append(prefix, next), limit-len(ret))...)
+		}
+	}
+	return ret
+}
+
+func (f *pathFinder[T, U]) noPathError() error {
+	var expected []string
+	seen := map[string]bool{}
+	for _, state := range f.furthestStates {
+		for _, name := range state.ValidTransitions() {
+			if !seen[name] {
+				seen[name] = true
+				expected = append(expected, name)
+			}
+		}
+	}
+
+	err := &NoPathError[U]{Index: f.furthest, Expected: expected}
+	if f.furthest == len(f.values) {
+		err.Eof = true
+	} else {
+		err.Value = f.values[f.furthest]
+	}
+	return err
+}
+
+func newAmbiguityError[T any, U any](paths [][]*State[T, U]) *AmbiguityError {
+	err := &AmbiguityError{Index: -1}
+	for _, path := range paths {
+		names := make([]string, 0, len(path))
+		for i, state := range path {
+			names = append(names, state.stateName)
+			if err.Index == -1 && paths[0][i] != state {
+				err.Index = i
+			}
+		}
+		err.Paths = append(err.Paths, names)
+	}
+	return err
+}
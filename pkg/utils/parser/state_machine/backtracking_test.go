@@ -0,0 +1,95 @@
+package state_machine
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backtracking", func() {
+	anyValue := func(value string) bool { return true }
+
+	// a list of values, followed by AND and by one or more values. `VALUE` accepts `AND` too and is tried first.
+	createStateMachine := func(observed *[]string) *State[TokenFamily, string] {
+		definition := StateMachineDefinition[TokenFamily, string]{
+			States: []StateDefinition[TokenFamily, string]{
+				{Name: "VALUE", Acceptor: anyValue},
+				{Name: "AND", Acceptor: makeAcceptorForString("AND")},
+				{Name: "LAST", Acceptor: anyValue},
+			},
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"VALUE"}},
+				{StateName: "VALUE", ValidTransitions: []string{"VALUE", "AND"}},
+				{StateName: "AND", ValidTransitions: []string{"LAST"}},
+				{StateName: "LAST", ValidTransitions: []string{"LAST", EndState}},
+			},
+		}
+		return NewStateMachineBuilder[TokenFamily, string]().
+			WithStateMachineDefinition(&definition).
+			WithTransitionObserver(func(from, to *State[TokenFamily, string], value string) {
+				*observed = append(*observed, to.Name())
+			}).
+			Build()
+	}
+
+	names := func(path []*State[TokenFamily, string]) []string {
+		var ret []string
+		for _, s := range path {
+			ret = append(ret, s.Name())
+		}
+		return ret
+	}
+
+	DescribeTable("Finding paths", func(values []string, mode ResolutionMode, expected []string, expectedErr string) {
+		var observed []string
+		path, err := createStateMachine(&observed).FindPath(values, mode)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(expectedErr))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names(path)).To(Equal(expected))
+		Expect(observed).To(BeEmpty(), "observers are not called while searching the path")
+	},
+		Entry("first match fails", []string{"a", "b", "AND", "c"}, FirstMatch, nil, "EOF encountered before reaching a final state"),
+		Entry("backtracking", []string{"a", "b", "AND", "c"}, Backtracking, []string{"VALUE", "VALUE", "AND", "LAST"}, ""),
+		Entry("strict backtracking", []string{"a", "b", "AND", "c"}, StrictBacktracking, []string{"VALUE", "VALUE", "AND", "LAST"}, ""),
+		Entry("the first path wins", []string{"a", "AND", "AND", "b"}, Backtracking, []string{"VALUE", "VALUE", "AND", "LAST"}, ""),
+		Entry("ambiguous", []string{"a", "AND", "AND", "b"}, StrictBacktracking, nil,
+			"ambiguous input: more than one path accepts all the values: [VALUE VALUE AND LAST], [VALUE AND LAST LAST]"),
+		Entry("no path", []string{"a", "b"}, Backtracking, nil, "EOF encountered before reaching a final state"),
+	)
+
+	It("Reports where the parsing failed", func() {
+		var observed []string
+		_, err := createStateMachine(&observed).FindPath([]string{"AND", "AND"}, Backtracking)
+		var noPath *NoPathError[string]
+		Expect(errors.As(err, &noPath)).To(BeTrue())
+		Expect(*noPath).To(Equal(NoPathError[string]{Index: 2, Eof: true, Expected: []string{"VALUE", "AND", "LAST"}}))
+
+		var ambiguity *AmbiguityError
+		_, err = createStateMachine(&observed).FindPath([]string{"a", "AND", "AND", "b"}, StrictBacktracking)
+		Expect(errors.As(err, &ambiguity)).To(BeTrue())
+		Expect(ambiguity.Index).To(Equal(1))
+	})
+
+	It("Follows the path calling the observers", func() {
+		var observed []string
+		start := createStateMachine(&observed)
+		values := []string{"a", "AND", "b"}
+		path, err := start.FindPath(values, Backtracking)
+		Expect(err).ToNot(HaveOccurred())
+
+		state := start
+		for i, next := range path {
+			Expect(state.MoveTo(next, values[i])).To(Succeed())
+			state = next
+		}
+		Expect(state.Eof()).To(BeTrue())
+		// like with Move, the transitions from the start state are not observed
+		Expect(observed).To(Equal([]string{"AND", "LAST"}))
+
+		Expect(start.MoveTo(path[1], "AND")).To(MatchError("unexpected token `AND`"))
+	})
+})
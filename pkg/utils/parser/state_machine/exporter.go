@@ -0,0 +1,227 @@
+package state_machine
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// diagram - the graph of a state machine, ready to be rendered
+type diagram struct {
+	nodes []diagramNode
+	edges [][2]string
+}
+
+type diagramNode struct {
+	name     string
+	data     string
+	acceptor string
+	start    bool
+	end      bool
+	// undefined is true for the states referenced by a transition but not defined
+	undefined bool
+}
+
+// ExportDOT - returns the Graphviz DOT representation of the state machine definition
+func ExportDOT[T any, U any](definition *StateMachineDefinition[T, U]) string {
+	return definitionDiagram(definition).dot()
+}
+
+// ExportMermaid - returns the Mermaid flowchart representation of the state machine definition
+func ExportMermaid[T any, U any](definition *StateMachineDefinition[T, U]) string {
+	return definitionDiagram(definition).mermaid()
+}
+
+// ExportStateDOT - returns the Graphviz DOT representation of the states reachable from the received (start) state
+func ExportStateDOT[T any, U any](start *State[T, U]) string {
+	return stateDiagram(start).dot()
+}
+
+// ExportStateMermaid - returns the Mermaid flowchart representation of the states reachable from the received (start) state
+func ExportStateMermaid[T any, U any](start *State[T, U]) string {
+	return stateDiagram(start).mermaid()
+}
+
+func definitionDiagram[T any, U any](definition *StateMachineDefinition[T, U]) *diagram {
+	d := &diagram{}
+	known := map[string]bool{StartState: true, EndState: true}
+
+	d.nodes = append(d.nodes, diagramNode{name: StartState, start: true})
+	for _, state := range definition.States {
+		known[state.Name] = true
+		d.nodes = append(d.nodes, diagramNode{
+			name:     state.Name,
+			data:     dataLabel(state.StateData),
+			acceptor: acceptorType(state.Acceptor),
+		})
+	}
+	d.nodes = append(d.nodes, diagramNode{name: EndState, end: true})
+
+	for _, transition := range definition.Transitions {
+		for _, target := range transition.ValidTransitions {
+			for _, name := range []string{transition.StateName, target} {
+				if !known[name] {
+					known[name] = true
+					d.nodes = append(d.nodes, diagramNode{name: name, undefined: true})
+				}
+			}
+			d.edges = append(d.edges, [2]string{transition.StateName, target})
+		}
+	}
+	return d
+}
+
+func stateDiagram[T any, U any](start *State[T, U]) *diagram {
+	d := &diagram{}
+	end := false
+	visited := map[*State[T, U]]bool{start: true}
+	queue := []*State[T, U]{start}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		name := state.stateName
+		node := diagramNode{name: name}
+		if state == start {
+			// the start state is named like in the definitions
+			name = StartState
+			node = diagramNode{name: name, start: true}
+		} else {
+			node.data = dataLabel(state.stateData)
+			node.acceptor = acceptorType(state.accept)
+		}
+		d.nodes = append(d.nodes, node)
+
+		for _, next := range state.next {
+			nextName := next.stateName
+			if next == start {
+				nextName = StartState
+			}
+			d.edges = append(d.edges, [2]string{name, nextName})
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+		if state.last {
+			end = true
+			d.edges = append(d.edges, [2]string{name, EndState})
+		}
+	}
+
+	if end {
+		d.nodes = append(d.nodes, diagramNode{name: EndState, end: true})
+	}
+	return d
+}
+
+func (d *diagram) dot() string {
+	sb := strings.Builder{}
+	sb.WriteString("digraph StateMachine {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	for _, node := range d.nodes {
+		var shape string
+		switch {
+		case node.start:
+			shape = "circle"
+		case node.end:
+			shape = "doublecircle"
+		case node.undefined:
+			shape = "box, style=dashed"
+		default:
+			shape = "box"
+		}
+		sb.WriteString(fmt.Sprintf("  %s [label=%s, shape=%s];\n", dotQuote(node.name), dotQuote(strings.Join(node.label(), "\n")), shape))
+	}
+	for _, edge := range d.edges {
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", dotQuote(edge[0]), dotQuote(edge[1])))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func (d *diagram) mermaid() string {
+	ids := make(map[string]string, len(d.nodes))
+	sb := strings.Builder{}
+	sb.WriteString("flowchart LR\n")
+	for i, node := range d.nodes {
+		id := fmt.Sprintf("s%d", i)
+		ids[node.name] = id
+
+		label := mermaidQuote(strings.Join(node.label(), "<br/>"))
+		switch {
+		case node.start:
+			sb.WriteString(fmt.Sprintf("  %s((%s))\n", id, label))
+		case node.end:
+			sb.WriteString(fmt.Sprintf("  %s(((%s)))\n", id, label))
+		case node.undefined:
+			sb.WriteString(fmt.Sprintf("  %s{{%s}}\n", id, label))
+		default:
+			sb.WriteString(fmt.Sprintf("  %s[%s]\n", id, label))
+		}
+	}
+	for _, edge := range d.edges {
+		sb.WriteString(fmt.Sprintf("  %s --> %s\n", ids[edge[0]], ids[edge[1]]))
+	}
+	return sb.String()
+}
+
+// label returns the lines of the label of the node
+func (n *diagramNode) label() []string {
+	switch {
+	case n.start:
+		return []string{"START"}
+	case n.end:
+		return []string{"END"}
+	case n.undefined:
+		return []string{n.name, "undefined"}
+	}
+	ret := []string{n.name}
+	if n.data != "" {
+		ret = append(ret, "data: "+n.data)
+	}
+	if n.acceptor != "" {
+		ret = append(ret, "acceptor: "+n.acceptor)
+	}
+	return ret
+}
+
+// dataLabel returns the textual representation of the data of a state, or an empty string for zero values
+func dataLabel[T any](data T) string {
+	v := reflect.ValueOf(&data).Elem()
+	if v.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(data)
+}
+
+// anonymousFunctionSuffix - matches the suffixes of the names of closures (ie: `.func1`, `.func1.2` or `.1` when inlined)
+// and method values (`-fm`)
+var anonymousFunctionSuffix = regexp.MustCompile(`((\.func\d+|\.\d+)+|-fm)$`)
+
+// acceptorType returns the name of the function that created the acceptor (ie: `string_parser.RegexpAcceptor`)
+func acceptorType[U any](acceptor func(value U) bool) string {
+	if acceptor == nil {
+		return ""
+	}
+	f := runtime.FuncForPC(reflect.ValueOf(acceptor).Pointer())
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return anonymousFunctionSuffix.ReplaceAllString(name, "")
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
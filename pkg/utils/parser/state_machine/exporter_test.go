@@ -0,0 +1,85 @@
+package state_machine
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporter", func() {
+	definition := StateMachineDefinition[TokenFamily, string]{
+		States: []StateDefinition[TokenFamily, string]{
+			{Name: "NEW", StateData: "OPEN", Acceptor: makeAcceptorForString("NEW")},
+			{Name: `"DONE"`, Acceptor: makeAcceptorForString("DONE")},
+		},
+		Transitions: []TransitionDefinition{
+			{StateName: StartState, ValidTransitions: []string{"NEW"}},
+			{StateName: "NEW", ValidTransitions: []string{`"DONE"`, "NEW"}},
+			{StateName: `"DONE"`, ValidTransitions: []string{EndState}},
+		},
+	}
+
+	It("Exports the definition as DOT", func() {
+		Expect(ExportDOT(&definition)).To(Equal(`digraph StateMachine {
+  rankdir=LR;
+  "__$$_START_$$__" [label="START", shape=circle];
+  "NEW" [label="NEW\ndata: OPEN\nacceptor: state_machine.makeAcceptorForString", shape=box];
+  "\"DONE\"" [label="\"DONE\"\nacceptor: state_machine.makeAcceptorForString", shape=box];
+  "__$$_END_$$__" [label="END", shape=doublecircle];
+  "__$$_START_$$__" -> "NEW";
+  "NEW" -> "\"DONE\"";
+  "NEW" -> "NEW";
+  "\"DONE\"" -> "__$$_END_$$__";
+}
+`))
+	})
+
+	It("Exports the definition as Mermaid", func() {
+		Expect(ExportMermaid(&definition)).To(Equal(`flowchart LR
+  s0(("START"))
+  s1["NEW<br/>data: OPEN<br/>acceptor: state_machine.makeAcceptorForString"]
+  s2["#quot;DONE#quot;<br/>acceptor: state_machine.makeAcceptorForString"]
+  s3((("END")))
+  s0 --> s1
+  s1 --> s2
+  s1 --> s1
+  s2 --> s3
+`))
+	})
+
+	It("Exports a built state machine like its definition", func() {
+		start := NewStateMachineBuilder[TokenFamily, string]().
+			WithStateMachineDefinition(&definition).
+			Build()
+		Expect(ExportStateDOT(start)).To(Equal(ExportDOT(&definition)))
+		Expect(ExportStateMermaid(start)).To(Equal(ExportMermaid(&definition)))
+	})
+
+	It("Exports the transitions back to the start state", func() {
+		loop := StateMachineDefinition[TokenFamily, string]{
+			States: []StateDefinition[TokenFamily, string]{
+				{Name: "NEW", Acceptor: makeAcceptorForString("NEW")},
+			},
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"NEW"}},
+				{StateName: "NEW", ValidTransitions: []string{StartState, EndState}},
+			},
+		}
+		start := NewStateMachineBuilder[TokenFamily, string]().
+			WithStateMachineDefinition(&loop).
+			Build()
+		Expect(ExportStateDOT(start)).To(ContainSubstring(`"NEW" -> "__$$_START_$$__";`))
+		Expect(ExportStateMermaid(start)).To(ContainSubstring("s1 --> s0\n"))
+		Expect(ExportStateDOT(start)).To(Equal(ExportDOT(&loop)))
+		Expect(ExportStateMermaid(start)).To(Equal(ExportMermaid(&loop)))
+	})
+
+	It("Marks the undefined states", func() {
+		broken := StateMachineDefinition[TokenFamily, string]{
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"MISSING"}},
+			},
+		}
+		Expect(ExportDOT(&broken)).To(ContainSubstring(`"MISSING" [label="MISSING\nundefined", shape=box, style=dashed];`))
+		Expect(ExportMermaid(&broken)).To(ContainSubstring(`s2{{"MISSING<br/>undefined"}}`))
+	})
+})
@@ -9,6 +9,7 @@ type State[T any, U any] struct {
 	stateName string
 	stateData T
 	accept    Acceptor[U]
+	examples  []U
 
 	last  bool
 	isEof bool
@@ -18,6 +19,18 @@ type State[T any, U any] struct {
 	observers         []TransitionObserver[T, U]
 }
 
+// UnexpectedTokenError is returned by Move when none of the next states accepts the received value
+type UnexpectedTokenError[U any] struct {
+	// Value - the value that has been refused
+	Value U
+	// Expected - the names of the states that could have been reached from the current state
+	Expected []string
+}
+
+func (e *UnexpectedTokenError[U]) Error() string {
+	return fmt.Sprintf("unexpected token `%v`", e.Value)
+}
+
 type Acceptor[U any] func(value U) bool
 type TransitionInterceptor[T any, U any] func(from, to *State[T, U], value U) error
 type TransitionObserver[T any, U any] func(from, to *State[T, U], value U)
@@ -32,23 +45,80 @@ func (s *State[T, U]) Data() T {
 }
 
 func (s *State[T, U]) Move(value U) (*State[T, U], error) {
+	return s.move(value, nil)
+}
+
+// move is Move calling the received interceptor, if any, after the one of the next state and before the observers
+func (s *State[T, U]) move(value U, interceptor TransitionInterceptor[T, U]) (*State[T, U], error) {
 	for _, next := range s.next {
 		if next.accept(value) {
 			// valid Value
-			if next.onStateTransition != nil {
-				if err := next.onStateTransition(s, next, value); err != nil {
-					return nil, err
-				}
-			}
-
-			for _, observer := range s.observers {
-				observer(s, next, value)
+			if err := s.transition(next, value, interceptor); err != nil {
+				return nil, err
 			}
 			return next, nil
 		}
 	}
 
-	return nil, fmt.Errorf("unexpected token `%v`", value)
+	return nil, &UnexpectedTokenError[U]{Value: value, Expected: s.ValidTransitions()}
+}
+
+// MoveTo moves to the received next state, that must be one of the states following this state and must accept the value.
+// Used to follow the paths returned by FindPath: interceptors and observers are called like in Move.
+func (s *State[T, U]) MoveTo(next *State[T, U], value U) error {
+	return s.moveTo(next, value, nil)
+}
+
+func (s *State[T, U]) moveTo(next *State[T, U], value U, interceptor TransitionInterceptor[T, U]) error {
+	for _, n := range s.next {
+		if n == next && next.accept(value) {
+			return s.transition(next, value, interceptor)
+		}
+	}
+	return &UnexpectedTokenError[U]{Value: value, Expected: s.ValidTransitions()}
+}
+
+// transition calls the interceptors and, only if none of them refuses the transition, the observers
+func (s *State[T, U]) transition(next *State[T, U], value U, interceptor TransitionInterceptor[T, U]) error {
+	if next.onStateTransition != nil {
+		if err := next.onStateTransition(s, next, value); err != nil {
+			return err
+		}
+	}
+	if interceptor != nil {
+		if err := interceptor(s, next, value); err != nil {
+			return err
+		}
+	}
+
+	for _, observer := range s.observers {
+		observer(s, next, value)
+	}
+	return nil
+}
+
+// Examples returns the examples of the values accepted by this state (see StateDefinition.Examples)
+func (s *State[T, U]) Examples() []U {
+	return s.examples
+}
+
+// Accepts returns true if this state accepts the value. Interceptors and observers are not called.
+func (s *State[T, U]) Accepts(value U) bool {
+	return s.accept != nil && s.accept(value)
+}
+
+// NextStates returns the states that can follow this state, in transitions order
+func (s *State[T, U]) NextStates() []*State[T, U] {
+	return append([]*State[T, U]{}, s.next...)
+}
+
+// ValidTransitions returns the names of the states that can follow this state
+func (s *State[T, U]) ValidTransitions() []string {
+	ret := make([]string, 0, len(s.next))
+	for _, next := range s.next {
+		ret = append(ret, next.stateName)
+	}
+	return ret
 }
 
 func (s *State[T, U]) Eof() bool {
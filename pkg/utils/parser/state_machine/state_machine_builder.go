@@ -10,6 +10,9 @@ type StateDefinition[T any, U any] struct {
 	StateData   T
 	Acceptor    func(value U) bool
 	OnIntercept func(data T, value U) error
+	// Examples - optional values accepted by the state. Used by ValidateDefinition to detect the states whose acceptor
+	// is shadowed by the acceptor of another state and to suggest the next values (ie: by string_parser.StringParser.Complete)
+	Examples []U
 }
 
 type StateMachineDefinition[T any, U any] struct {
@@ -25,13 +28,26 @@ type TransitionDefinition struct {
 type StateMachineBuilder[T any, U any] interface {
 	WithTransitionInterceptor(handler TransitionInterceptor[T, U]) StateMachineBuilder[T, U]
 	WithTransitionObserver(observer TransitionObserver[T, U]) StateMachineBuilder[T, U]
+	// Build - builds the state machine and returns the start state. The transitions from or to unknown states are
+	// ignored: use BuildE (see StateMachineBuilderE) or ValidateDefinition to detect them
 	Build() *State[T, U]
 }
 
+// StateMachineBuilderE - implemented by the builders returned by NewStateMachineBuilder. It is a separate interface so
+// that the implementations of StateMachineBuilder outside this package don't have to implement it.
+type StateMachineBuilderE[T any, U any] interface {
+	StateMachineBuilder[T, U]
+	// BuildE - validates the definition (see ValidateDefinition) and builds the state machine. Returns a
+	// *DefinitionError if the definition is not valid
+	BuildE() (*State[T, U], error)
+}
+
 type StateMachineConfigurator[T any, U any] interface {
 	WithStateMachineDefinition(definition *StateMachineDefinition[T, U]) StateMachineBuilder[T, U]
 }
 
+var _ StateMachineBuilderE[string, string] = &stateMachineBuilder[string, string]{}
+
 type stateMachineBuilder[T any, U any] struct {
 	definition            *StateMachineDefinition[T, U]
 	transitionInterceptor TransitionInterceptor[T, U]
@@ -53,6 +69,13 @@ func (smb *stateMachineBuilder[T, U]) WithTransitionObserver(observer Transition
 	return smb
 }
 
+func (smb *stateMachineBuilder[T, U]) BuildE() (*State[T, U], error) {
+	if err := ValidateDefinition(smb.definition); err != nil {
+		return nil, err
+	}
+	return smb.Build(), nil
+}
+
 func (smb *stateMachineBuilder[T, U]) Build() *State[T, U] {
 	stateMap := make(map[string]*State[T, U])
 
@@ -61,20 +84,27 @@ func (smb *stateMachineBuilder[T, U]) Build() *State[T, U] {
 
 	// build all the tokens
 	for _, t := range smb.definition.States {
-		stateMap[t.Name] = NewStateBuilder[T, U](t.Name).
+		state := NewStateBuilder[T, U](t.Name).
 			Data(t.StateData).
 			Accept(t.Acceptor).
 			WithTransitionInterceptor(smb.transitionInterceptor).
 			WithTransitionObserver(smb.observers...).
 			Build()
+		// the examples are only known through the definition, so StateBuilder doesn't need to expose them
+		state.examples = t.Examples
+		stateMap[t.Name] = state
 	}
 
 	// add all the transitions
 	for _, transition := range smb.definition.Transitions {
-		currentState := stateMap[transition.StateName]
+		currentState, ok := stateMap[transition.StateName]
+		if !ok {
+			continue
+		}
 		for _, targetStateName := range transition.ValidTransitions {
-			targetState := stateMap[targetStateName]
-			currentState.addNextState(targetState)
+			if targetState, ok := stateMap[targetStateName]; ok {
+				currentState.addNextState(targetState)
+			}
 		}
 	}
 
@@ -1,6 +1,8 @@
 package state_machine
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -78,4 +80,16 @@ var _ = Describe("State Machine Tests", func() {
 		Entry("From REVIEW to ASSIGNED", []string{"NEW", "ASSIGNED", "IN PROGRESS", "WAITING FOR REVIEW", "ASSIGNED"}, "unexpected token `ASSIGNED`"),
 		Entry("END STATE NOT REACHED", []string{"NEW", "ASSIGNED", "IN PROGRESS", "WAITING FOR REVIEW"}, ""),
 	)
+
+	It("Reports the expected states", func() {
+		currentState, err := createTestStateMachine().Move("NEW")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(currentState.ValidTransitions()).To(Equal([]string{"ASSIGNED", "WON'T DO"}))
+
+		_, err = currentState.Move("DONE")
+		var unexpectedToken *UnexpectedTokenError[string]
+		Expect(errors.As(err, &unexpectedToken)).To(BeTrue())
+		Expect(unexpectedToken.Value).To(Equal("DONE"))
+		Expect(unexpectedToken.Expected).To(Equal([]string{"ASSIGNED", "WON'T DO"}))
+	})
 })
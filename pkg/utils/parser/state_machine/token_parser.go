@@ -0,0 +1,125 @@
+package state_machine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TokenParseError - the error returned when a stream of tokens can't be parsed
+type TokenParseError[U any] struct {
+	// Index - the index of the offending token. When Eof is true, this is the number of tokens
+	Index int
+	// Token - the offending token. Zero value when Eof is true
+	Token U
+	// Eof - true if the end of the tokens has been reached before the parsing could be completed
+	Eof bool
+	// Expected - the names of the states that would have been valid at Index. Empty if the token was valid but has been
+	// refused for other reasons (ie: by a transition interceptor)
+	Expected []string
+	// Err - the underlying error
+	Err error
+}
+
+func (e *TokenParseError[U]) Error() string {
+	if e.Eof {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("[%d] error parsing the tokens: %v", e.Index+1, e.Err)
+}
+
+func (e *TokenParseError[U]) Unwrap() error {
+	return e.Err
+}
+
+// TokenParser - parses streams of tokens of any type with a state machine, ie: the string_scanner.Token returned by a
+// scanner. The acceptors and the interceptors receive the whole token, so they can check its type or its position.
+// The TokenParser is safe for concurrent use as long as the interceptors and observers of the state machine are.
+type TokenParser[T any, U any] struct {
+	start          *State[T, U]
+	resolutionMode ResolutionMode
+}
+
+// NewTokenParser - returns a parser using the state machine starting from the received state (see StateMachineBuilder.Build).
+// resolutionMode configures how the tokens accepted by more than one of the next states are resolved.
+func NewTokenParser[T any, U any](start *State[T, U], resolutionMode ResolutionMode) *TokenParser[T, U] {
+	return &TokenParser[T, U]{start: start, resolutionMode: resolutionMode}
+}
+
+// Start returns the start state of the state machine used by the parser
+func (p *TokenParser[T, U]) Start() *State[T, U] {
+	return p.start
+}
+
+// Parse - parses the tokens. Returns a *TokenParseError if the tokens are not valid.
+func (p *TokenParser[T, U]) Parse(tokens []U) error {
+	return p.ParseWithInterceptor(tokens, nil)
+}
+
+// ParseWithInterceptor - parses the tokens calling the received interceptor (after the ones of the state machine) for
+// each transition. Since the interceptor is used only for the current call, it can safely keep the state of the parsing.
+// Like the ones of the state machine, it is called before the observers: they never see the transitions it refuses.
+func (p *TokenParser[T, U]) ParseWithInterceptor(tokens []U, interceptor TransitionInterceptor[T, U]) error {
+	if p.resolutionMode != FirstMatch {
+		return p.parseWithBacktracking(tokens, interceptor)
+	}
+
+	state := p.start
+	for i, token := range tokens {
+		next, err := state.move(token, interceptor)
+		if err != nil {
+			parseError := &TokenParseError[U]{Index: i, Token: token, Err: err}
+			var unexpectedToken *UnexpectedTokenError[U]
+			if errors.As(err, &unexpectedToken) {
+				parseError.Expected = unexpectedToken.Expected
+			}
+			return parseError
+		}
+		state = next
+	}
+
+	if !state.Eof() {
+		return newEofError[U](len(tokens), state.ValidTransitions())
+	}
+	return nil
+}
+
+// parseWithBacktracking finds the path accepting all the tokens, then follows it calling the interceptors: this way the
+// interceptors never see the branches that have been discarded
+func (p *TokenParser[T, U]) parseWithBacktracking(tokens []U, interceptor TransitionInterceptor[T, U]) error {
+	path, err := p.start.FindPath(tokens, p.resolutionMode)
+	var noPath *NoPathError[U]
+	var ambiguity *AmbiguityError
+	switch {
+	case errors.As(err, &noPath) && noPath.Eof:
+		return newEofError[U](len(tokens), noPath.Expected)
+	case errors.As(err, &noPath):
+		return &TokenParseError[U]{
+			Index:    noPath.Index,
+			Token:    noPath.Value,
+			Expected: noPath.Expected,
+			Err:      &UnexpectedTokenError[U]{Value: noPath.Value, Expected: noPath.Expected},
+		}
+	case errors.As(err, &ambiguity):
+		return &TokenParseError[U]{Index: ambiguity.Index, Token: tokens[ambiguity.Index], Err: err}
+	case err != nil:
+		return err
+	}
+
+	state := p.start
+	for i, next := range path {
+		if err := state.moveTo(next, tokens[i], interceptor); err != nil {
+			return &TokenParseError[U]{Index: i, Token: tokens[i], Err: err}
+		}
+		state = next
+	}
+	return nil
+}
+
+func newEofError[U any](index int, expected []string) *TokenParseError[U] {
+	return &TokenParseError[U]{
+		Index:    index,
+		Eof:      true,
+		Expected: expected,
+		Err:      fmt.Errorf(`EOF encountered while parsing the tokens`),
+	}
+}
@@ -0,0 +1,128 @@
+package state_machine
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenParser", func() {
+	type token struct {
+		kind     string
+		value    string
+		position int
+	}
+	kindAcceptor := func(kind string) func(t token) bool {
+		return func(t token) bool { return t.kind == kind }
+	}
+
+	// `key = value [, key = value ...]`
+	definition := StateMachineDefinition[string, token]{
+		States: []StateDefinition[string, token]{
+			{Name: "KEY", Acceptor: kindAcceptor("word")},
+			{Name: "EQ", Acceptor: func(t token) bool { return t.kind == "op" && t.value == "=" }},
+			{Name: "VALUE", Acceptor: kindAcceptor("word")},
+			{Name: "COMMA", Acceptor: func(t token) bool { return t.kind == "op" && t.value == "," }},
+		},
+		Transitions: []TransitionDefinition{
+			{StateName: StartState, ValidTransitions: []string{"KEY"}},
+			{StateName: "KEY", ValidTransitions: []string{"EQ"}},
+			{StateName: "EQ", ValidTransitions: []string{"VALUE"}},
+			{StateName: "VALUE", ValidTransitions: []string{"COMMA", EndState}},
+			{StateName: "COMMA", ValidTransitions: []string{"KEY"}},
+		},
+	}
+	newParser := func(mode ResolutionMode) *TokenParser[string, token] {
+		start := NewStateMachineBuilder[string, token]().WithStateMachineDefinition(&definition).Build()
+		return NewTokenParser(start, mode)
+	}
+
+	tokens := []token{{"word", "a", 0}, {"op", "=", 1}, {"word", "b", 2}, {"op", ",", 3}, {"word", "c", 4}, {"op", "=", 5}, {"word", "d", 6}}
+
+	DescribeTable("Parsing", func(mode ResolutionMode) {
+		parser := newParser(mode)
+
+		var positions []int
+		err := parser.ParseWithInterceptor(tokens, func(from, to *State[string, token], value token) error {
+			positions = append(positions, value.position)
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(positions).To(Equal([]int{0, 1, 2, 3, 4, 5, 6}))
+
+		err = parser.Parse([]token{{"word", "a", 0}, {"word", "b", 1}})
+		var parseError *TokenParseError[token]
+		Expect(errors.As(err, &parseError)).To(BeTrue())
+		Expect(parseError.Index).To(Equal(1))
+		Expect(parseError.Token).To(Equal(token{"word", "b", 1}))
+		Expect(parseError.Expected).To(Equal([]string{"EQ"}))
+		Expect(err.Error()).To(Equal("[2] error parsing the tokens: unexpected token `{word b 1}`"))
+
+		err = parser.Parse(tokens[:2])
+		Expect(errors.As(err, &parseError)).To(BeTrue())
+		Expect(parseError.Eof).To(BeTrue())
+		Expect(parseError.Index).To(Equal(2))
+		Expect(parseError.Expected).To(Equal([]string{"VALUE"}))
+
+		err = parser.ParseWithInterceptor(tokens, func(from, to *State[string, token], value token) error {
+			if to.Name() == "VALUE" && value.value == "d" {
+				return errors.New("invalid value")
+			}
+			return nil
+		})
+		Expect(errors.As(err, &parseError)).To(BeTrue())
+		Expect(parseError.Index).To(Equal(6))
+		Expect(parseError.Expected).To(BeEmpty())
+		Expect(parseError.Err).To(MatchError("invalid value"))
+	},
+		Entry("first match", FirstMatch),
+		Entry("backtracking", Backtracking),
+		Entry("strict backtracking", StrictBacktracking),
+	)
+
+	DescribeTable("Notifies the observers only of the transitions accepted by the interceptor", func(mode ResolutionMode) {
+		var observed []int
+		start := NewStateMachineBuilder[string, token]().
+			WithStateMachineDefinition(&definition).
+			WithTransitionObserver(func(from, to *State[string, token], value token) {
+				observed = append(observed, value.position)
+			}).
+			Build()
+
+		err := NewTokenParser(start, mode).ParseWithInterceptor(tokens, func(from, to *State[string, token], value token) error {
+			if value.position == 2 {
+				return errors.New("invalid value")
+			}
+			return nil
+		})
+		Expect(err).To(MatchError(ContainSubstring("invalid value")))
+		Expect(observed).To(ContainElement(1))
+		Expect(observed).NotTo(ContainElement(2))
+	},
+		Entry("first match", FirstMatch),
+		Entry("backtracking", Backtracking),
+	)
+
+	It("Reports ambiguities", func() {
+		ambiguous := StateMachineDefinition[string, token]{
+			States: []StateDefinition[string, token]{
+				{Name: "A", Acceptor: kindAcceptor("word")},
+				{Name: "B", Acceptor: kindAcceptor("word")},
+			},
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"A", "B"}},
+				{StateName: "A", ValidTransitions: []string{EndState}},
+				{StateName: "B", ValidTransitions: []string{EndState}},
+			},
+		}
+		start := NewStateMachineBuilder[string, token]().WithStateMachineDefinition(&ambiguous).Build()
+
+		Expect(NewTokenParser(start, Backtracking).Parse([]token{{"word", "a", 0}})).To(Succeed())
+
+		err := NewTokenParser(start, StrictBacktracking).Parse([]token{{"word", "a", 0}})
+		var ambiguity *AmbiguityError
+		Expect(errors.As(err, &ambiguity)).To(BeTrue())
+		Expect(ambiguity.Paths).To(Equal([][]string{{"A"}, {"B"}}))
+	})
+})
@@ -0,0 +1,165 @@
+package state_machine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefinitionError - the error returned when validating an invalid StateMachineDefinition. It contains all the problems
+// that have been found: each of them can be checked with errors.As
+type DefinitionError struct {
+	Problems []error
+}
+
+func (e *DefinitionError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, problem.Error())
+	}
+	return fmt.Sprintf("invalid state machine definition: %s", strings.Join(problems, "; "))
+}
+
+func (e *DefinitionError) Unwrap() []error {
+	return e.Problems
+}
+
+// UnknownStateError - a transition references a state that has not been defined
+type UnknownStateError struct {
+	StateName string
+	// ReferencedBy - the name of the state whose transitions reference the unknown state. Empty if the unknown state is
+	// the source of the transitions
+	ReferencedBy string
+}
+
+func (e *UnknownStateError) Error() string {
+	if e.ReferencedBy == "" {
+		return fmt.Sprintf("transitions defined for unknown state '%s'", e.StateName)
+	}
+	return fmt.Sprintf("unknown state '%s' referenced by state '%s'", e.StateName, e.ReferencedBy)
+}
+
+// UnreachableStateError - the state can't be reached from the StartState
+type UnreachableStateError struct {
+	StateName string
+}
+
+func (e *UnreachableStateError) Error() string {
+	return fmt.Sprintf("state '%s' is unreachable", e.StateName)
+}
+
+// DeadEndStateError - the EndState can't be reached from the state
+type DeadEndStateError struct {
+	StateName string
+}
+
+func (e *DeadEndStateError) Error() string {
+	return fmt.Sprintf("state '%s' can't reach the end state", e.StateName)
+}
+
+// ShadowedStateError - all the examples of a state are accepted by a state that precedes it in the transitions of
+// another state: the first state always wins and the shadowed state is never reached from there
+type ShadowedStateError struct {
+	StateName  string
+	Shadowed   string
+	ShadowedBy string
+}
+
+func (e *ShadowedStateError) Error() string {
+	return fmt.Sprintf("transition from '%s' to '%s' is shadowed by the transition to '%s'", e.StateName, e.Shadowed, e.ShadowedBy)
+}
+
+// ValidateDefinition - checks the definition for unknown state names, unreachable states, states that can't reach the
+// EndState and overlapping acceptors (see StateDefinition.Examples). Returns a *DefinitionError or nil.
+func ValidateDefinition[T any, U any](definition *StateMachineDefinition[T, U]) error {
+	var problems []error
+
+	states := map[string]*StateDefinition[T, U]{}
+	for i := range definition.States {
+		states[definition.States[i].Name] = &definition.States[i]
+	}
+	known := func(name string) bool {
+		_, ok := states[name]
+		return ok || name == StartState || name == EndState
+	}
+
+	transitions := map[string][]string{}
+	for _, transition := range definition.Transitions {
+		if !known(transition.StateName) {
+			problems = append(problems, &UnknownStateError{StateName: transition.StateName})
+			continue
+		}
+		for _, target := range transition.ValidTransitions {
+			if !known(target) {
+				problems = append(problems, &UnknownStateError{StateName: target, ReferencedBy: transition.StateName})
+				continue
+			}
+			transitions[transition.StateName] = append(transitions[transition.StateName], target)
+		}
+	}
+
+	reverseTransitions := map[string][]string{}
+	for from, targets := range transitions {
+		for _, to := range targets {
+			reverseTransitions[to] = append(reverseTransitions[to], from)
+		}
+	}
+
+	reachable := visit(StartState, transitions)
+	canEnd := visit(EndState, reverseTransitions)
+	for _, state := range definition.States {
+		switch {
+		case !reachable[state.Name]:
+			problems = append(problems, &UnreachableStateError{StateName: state.Name})
+		case !canEnd[state.Name]:
+			problems = append(problems, &DeadEndStateError{StateName: state.Name})
+		}
+	}
+
+	// transitions are evaluated in order: a state is shadowed if all its examples are accepted by a previous state
+	for _, transition := range definition.Transitions {
+		targets := transitions[transition.StateName]
+		for j, target := range targets {
+			shadowed := states[target]
+			if shadowed == nil || len(shadowed.Examples) == 0 {
+				continue
+			}
+			for _, previous := range targets[:j] {
+				if previousState := states[previous]; previousState != nil && previousState.Acceptor != nil && acceptsAll(previousState.Acceptor, shadowed.Examples) {
+					problems = append(problems, &ShadowedStateError{StateName: transition.StateName, Shadowed: target, ShadowedBy: previous})
+					break
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &DefinitionError{Problems: problems}
+	}
+	return nil
+}
+
+// visit returns the names of the states that can be reached from the received state
+func visit(from string, transitions map[string][]string) map[string]bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range transitions[current] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return visited
+}
+
+func acceptsAll[U any](acceptor func(value U) bool, values []U) bool {
+	for _, value := range values {
+		if !acceptor(value) {
+			return false
+		}
+	}
+	return true
+}
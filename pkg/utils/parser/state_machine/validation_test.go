@@ -0,0 +1,88 @@
+package state_machine
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Definition validation", func() {
+	prefixAcceptor := func(prefix string) func(value string) bool {
+		return func(value string) bool { return strings.HasPrefix(value, prefix) }
+	}
+
+	It("Accepts a valid definition", func() {
+		definition := StateMachineDefinition[TokenFamily, string]{
+			States: []StateDefinition[TokenFamily, string]{
+				{Name: "NEW", Acceptor: makeAcceptorForString("NEW"), Examples: []string{"NEW"}},
+				{Name: "DONE", Acceptor: makeAcceptorForString("DONE"), Examples: []string{"DONE"}},
+			},
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"NEW"}},
+				{StateName: "NEW", ValidTransitions: []string{"DONE", "NEW"}},
+				{StateName: "DONE", ValidTransitions: []string{EndState}},
+			},
+		}
+		Expect(ValidateDefinition(&definition)).To(Succeed())
+		builder := NewStateMachineBuilder[TokenFamily, string]().WithStateMachineDefinition(&definition)
+		start, err := builder.(StateMachineBuilderE[TokenFamily, string]).BuildE()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(start.NextStates()).To(HaveLen(1))
+	})
+
+	It("Reports all the problems", func() {
+		definition := StateMachineDefinition[TokenFamily, string]{
+			States: []StateDefinition[TokenFamily, string]{
+				{Name: "NEW", Acceptor: makeAcceptorForString("NEW")},
+				{Name: "ANY", Acceptor: prefixAcceptor("")},
+				{Name: "DONE", Acceptor: makeAcceptorForString("DONE"), Examples: []string{"DONE"}},
+				{Name: "LOOP", Acceptor: makeAcceptorForString("LOOP")},
+				{Name: "ORPHAN", Acceptor: makeAcceptorForString("ORPHAN")},
+			},
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"NEW"}},
+				{StateName: "NEW", ValidTransitions: []string{"ANY", "DONE", "LOOP", "DNOE"}},
+				{StateName: "ANY", ValidTransitions: []string{EndState}},
+				{StateName: "DONE", ValidTransitions: []string{EndState}},
+				{StateName: "LOOP", ValidTransitions: []string{"LOOP"}},
+				{StateName: "MISSING", ValidTransitions: []string{EndState}},
+			},
+		}
+		err := ValidateDefinition(&definition)
+		Expect(err).To(MatchError("invalid state machine definition: " +
+			"unknown state 'DNOE' referenced by state 'NEW'; " +
+			"transitions defined for unknown state 'MISSING'; " +
+			"state 'LOOP' can't reach the end state; " +
+			"state 'ORPHAN' is unreachable; " +
+			"transition from 'NEW' to 'DONE' is shadowed by the transition to 'ANY'"))
+
+		var definitionError *DefinitionError
+		Expect(errors.As(err, &definitionError)).To(BeTrue())
+		Expect(definitionError.Problems).To(HaveLen(5))
+
+		var shadowed *ShadowedStateError
+		Expect(errors.As(err, &shadowed)).To(BeTrue())
+		Expect(*shadowed).To(Equal(ShadowedStateError{StateName: "NEW", Shadowed: "DONE", ShadowedBy: "ANY"}))
+
+		var unreachable *UnreachableStateError
+		Expect(errors.As(err, &unreachable)).To(BeTrue())
+		Expect(unreachable.StateName).To(Equal("ORPHAN"))
+	})
+
+	It("Reports the unknown states when building with BuildE", func() {
+		definition := StateMachineDefinition[TokenFamily, string]{
+			Transitions: []TransitionDefinition{
+				{StateName: StartState, ValidTransitions: []string{"MISSING"}},
+			},
+		}
+		builder := NewStateMachineBuilder[TokenFamily, string]().WithStateMachineDefinition(&definition)
+		start, err := builder.(StateMachineBuilderE[TokenFamily, string]).BuildE()
+		Expect(err).To(MatchError("invalid state machine definition: unknown state 'MISSING' referenced by state '__$$_START_$$__'"))
+		Expect(start).To(BeNil())
+
+		// Build ignores the transitions to the unknown states
+		Expect(builder.Build().NextStates()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,103 @@
+package string_parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+)
+
+// Suggestion - a token that can follow the prefix passed to StringParser.Complete
+type Suggestion struct {
+	// Value - the suggested value. Empty when Placeholder is true
+	Value string
+	// Placeholder - true if the token is valid but no value is known for it (ie: a free value). The suggestion can't be
+	// inserted as is: it should be presented by TokenName or Family (ie: `<VALUE>`)
+	Placeholder bool
+	// TokenName - the name of the suggested token
+	TokenName string
+	// Family - the family of the suggested token (the StateData of the token definition)
+	Family string
+	// Position - the position (0 based) of the prefix where the suggestion starts: the text of the prefix from Position
+	// on must be replaced by Value
+	Position int
+}
+
+// SuggestionProvider - returns the values that can be suggested for a token (ie: the valid column names for a column
+// token). partial is the text typed so far for the token: the returned values are filtered by Complete, ignoring the case.
+type SuggestionProvider func(tokenName string, family string, partial string) []string
+
+// Complete - returns the tokens that can follow the prefix. If the prefix doesn't end with a space, its last token is
+// considered incomplete and the suggestions are the values that can replace it. The values of each token are taken from
+// the examples of the token definition and from the configured SuggestionProvider. Returns nil if the prefix is not valid.
+func (p *StringParser) Complete(prefix string) []Suggestion {
+	tokens := p.scan(prefix)
+
+	partial := ""
+	position := len(prefix)
+	if lastRune, _ := utf8.DecodeLastRuneInString(prefix); len(tokens) > 0 && !unicode.IsSpace(lastRune) {
+		last := tokens[len(tokens)-1]
+		partial, position = last.Value, last.Position
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	// all the states the prefix can lead to: more than one when backtracking
+	states := []*state_machine.State[string, string]{p.parser.Start()}
+	for _, token := range tokens {
+		var nextStates []*state_machine.State[string, string]
+		for _, state := range states {
+			for _, next := range state.NextStates() {
+				if next.Accepts(token.Value) && !containsState(nextStates, next) {
+					nextStates = append(nextStates, next)
+					if p.resolutionMode == state_machine.FirstMatch {
+						break
+					}
+				}
+			}
+		}
+		if len(nextStates) == 0 {
+			return nil
+		}
+		states = nextStates
+	}
+
+	var ret []Suggestion
+	seen := map[Suggestion]bool{}
+	add := func(suggestion Suggestion) {
+		if !seen[suggestion] {
+			seen[suggestion] = true
+			ret = append(ret, suggestion)
+		}
+	}
+	for _, state := range states {
+		for _, next := range state.NextStates() {
+			family := fmt.Sprint(next.Data())
+			values := append([]string{}, next.Examples()...)
+			if p.suggestionProvider != nil {
+				values = append(values, p.suggestionProvider(next.Name(), family, partial)...)
+			}
+
+			for _, value := range values {
+				if len(value) >= len(partial) && strings.EqualFold(value[:len(partial)], partial) {
+					add(Suggestion{Value: value, TokenName: next.Name(), Family: family, Position: position})
+				}
+			}
+			if len(values) == 0 && (partial == "" || next.Accepts(partial)) {
+				// the token is valid, but we don't know what to suggest
+				add(Suggestion{TokenName: next.Name(), Family: family, Position: position, Placeholder: true})
+			}
+		}
+	}
+	return ret
+}
+
+func containsState(states []*state_machine.State[string, string], state *state_machine.State[string, string]) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package string_parser
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+)
+
+var _ = Describe("Completion", func() {
+	// the default scanner returns a token for each char: `a=1,b!2`
+	grammar, err := ParseGrammar(`
+		KEY   <KEY>   = /[a-z]/ ;
+		EQ            = "=" ;
+		NEQ           = "!" ;
+		VALUE <VALUE> = /[0-9]/ ;
+		COMMA         = "," ;
+		START -> KEY ;
+		KEY   -> EQ | NEQ ;
+		EQ    -> VALUE ;
+		NEQ   -> VALUE ;
+		VALUE -> COMMA | END ;
+		COMMA -> KEY ;
+	`)
+	Expect(err).ToNot(HaveOccurred())
+
+	provider := func(tokenName string, family string, partial string) []string {
+		if family == "KEY" {
+			return []string{"a", "b"}
+		}
+		return nil
+	}
+
+	DescribeTable("Suggestions", func(prefix string, expected []Suggestion) {
+		parser := NewStringParserBuilder().WithGrammar(grammar).WithSuggestionProvider(provider).Build()
+		Expect(parser.Complete(prefix)).To(Equal(expected))
+	},
+		Entry("empty prefix", "", []Suggestion{
+			{Value: "a", TokenName: "KEY", Family: "KEY"},
+			{Value: "b", TokenName: "KEY", Family: "KEY"},
+		}),
+		Entry("examples", "a", []Suggestion{
+			{Value: "a", TokenName: "KEY", Family: "KEY"},
+		}),
+		Entry("alternatives", "a!", []Suggestion{
+			{Value: "!", TokenName: "NEQ", Family: "", Position: 1},
+		}),
+		Entry("free values", "a=1", []Suggestion{
+			{TokenName: "VALUE", Family: "VALUE", Position: 2, Placeholder: true},
+		}),
+		Entry("examples after free values", "a=1,", []Suggestion{
+			{Value: ",", TokenName: "COMMA", Family: "", Position: 3},
+		}),
+		Entry("invalid prefix", "a=,", nil),
+		Entry("no match", "a=1,c", nil),
+	)
+
+	It("Follows all the paths when backtracking", func() {
+		grammar, err := ParseGrammar(`
+			ANY = /.*/ ; X = "x" ; Y = "y" ;
+			START -> ANY | X ; ANY -> END ; X -> Y ; Y -> END ;
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		parser := NewStringParserBuilder().WithGrammar(grammar).Build()
+		Expect(parser.Complete("xy")).To(BeEmpty())
+
+		parser = NewStringParserBuilder().WithGrammar(grammar).WithResolutionMode(state_machine.Backtracking).Build()
+		Expect(parser.Complete("xy")).To(Equal([]Suggestion{{Value: "y", TokenName: "Y", Family: "", Position: 1}}))
+	})
+})
@@ -3,6 +3,7 @@ package string_parser
 import (
 	"fmt"
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_scanner"
 	"regexp"
 	"strings"
 )
@@ -20,8 +21,39 @@ func RegexpAcceptor(regexpToAccept string) state_machine.Acceptor[string] {
 		regexpToAccept = fmt.Sprintf(`%s$`, regexpToAccept)
 	}
 
-	return func(currentValue string) bool {
-		matched, _ := regexp.Match(regexpToAccept, []byte(currentValue))
-		return matched
+	re, err := regexp.Compile(regexpToAccept)
+	if err != nil {
+		// invalid regular expressions never match
+		return func(currentValue string) bool { return false }
+	}
+	return func(currentValue string) bool { return re.MatchString(currentValue) }
+}
+
+// TokenTypeAcceptor - accepts the scanned tokens of one of the received types. To be used with the
+// state_machine.TokenParser, so that the tokens are not matched again against regular expressions.
+func TokenTypeAcceptor(tokenTypes ...int) state_machine.Acceptor[string_scanner.Token] {
+	return func(token string_scanner.Token) bool {
+		for _, tokenType := range tokenTypes {
+			if token.TokenType == tokenType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// TokenValueAcceptor - accepts the scanned tokens of the received type whose value is one of the received values, ignoring
+// the case (ie: keywords)
+func TokenValueAcceptor(tokenType int, values ...string) state_machine.Acceptor[string_scanner.Token] {
+	return func(token string_scanner.Token) bool {
+		if token.TokenType != tokenType {
+			return false
+		}
+		for _, value := range values {
+			if strings.EqualFold(token.Value, value) {
+				return true
+			}
+		}
+		return false
 	}
 }
@@ -16,7 +16,9 @@ type TokenTransitions struct {
 	ValidTransitions []string
 }
 
-func (g *Grammar) toStateMachineDefinition() *state_machine.StateMachineDefinition[string, string] {
+// StateMachineDefinition - returns the definition of the state machine implementing the grammar (ie: to export it with
+// state_machine.ExportDOT or state_machine.ExportMermaid)
+func (g *Grammar) StateMachineDefinition() *state_machine.StateMachineDefinition[string, string] {
 	ret := state_machine.StateMachineDefinition[string, string]{
 		States:      nil,
 		Transitions: nil,
@@ -31,6 +33,11 @@ func (g *Grammar) toStateMachineDefinition() *state_machine.StateMachineDefiniti
 	return &ret
 }
 
+// Validate - validates the grammar. See state_machine.ValidateDefinition
+func (g *Grammar) Validate() error {
+	return state_machine.ValidateDefinition(g.StateMachineDefinition())
+}
+
 func (tt *TokenTransitions) toStateTransitions() *state_machine.TransitionDefinition {
 	return &state_machine.TransitionDefinition{
 		StateName:        tt.TokenName,
@@ -0,0 +1,342 @@
+package string_parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+)
+
+const (
+	// grammarStart - the name of the start state in the grammar DSL
+	grammarStart = "START"
+	// grammarEnd - the name of the end state in the grammar DSL
+	grammarEnd = "END"
+)
+
+// GrammarSyntaxError - the error returned when a grammar definition can't be loaded
+type GrammarSyntaxError struct {
+	// Line - the line (1 based) of the error
+	Line int
+	// Column - the column (1 based) of the error
+	Column int
+	Err    error
+}
+
+func (e *GrammarSyntaxError) Error() string {
+	return fmt.Sprintf("[%d:%d] error loading the grammar: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *GrammarSyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// LoadGrammar - reads a grammar definition written with the grammar DSL. See ParseGrammar.
+func LoadGrammar(reader io.Reader) (Grammar, error) {
+	definition, err := io.ReadAll(reader)
+	if err != nil {
+		return Grammar{}, err
+	}
+	return ParseGrammar(string(definition))
+}
+
+// ParseGrammar - compiles a grammar definition written with the grammar DSL:
+//
+//	# comments start with '#'
+//	# tokens: NAME [<FAMILY>] = "literal" | /regexp/ ;
+//	COLUMN <COLUMN> = /(?i)[A-Z][A-Z0-9_.]*/ ;
+//	COMMA = "," ;
+//	# transitions: NAME -> NAME | NAME ... ; START and END are the start and the end states
+//	START -> COLUMN ;
+//	COLUMN -> COMMA | END ;
+//	COMMA -> COLUMN ;
+//
+// The family is stored into the StateData of the token. Literals are accepted with StringAcceptor and are the Examples of
+// their token, regular expressions are accepted with RegexpAcceptor. The returned grammar is validated with
+// Grammar.Validate, except for the shadowed tokens (see state_machine.ShadowedStateError): they are reached when
+// backtracking, so they are reported only by calling Grammar.Validate.
+func ParseGrammar(definition string) (Grammar, error) {
+	tokens, err := lexGrammar(definition)
+	if err != nil {
+		return Grammar{}, err
+	}
+
+	loader := &grammarLoader{tokens: tokens, transitions: map[string]int{}}
+	for !loader.eof() {
+		if err := loader.statement(); err != nil {
+			return Grammar{}, err
+		}
+	}
+
+	if err := withoutShadowedStates(loader.grammar.Validate()); err != nil {
+		return Grammar{}, err
+	}
+	return loader.grammar, nil
+}
+
+// withoutShadowedStates removes the *state_machine.ShadowedStateError problems from the validation error
+func withoutShadowedStates(err error) error {
+	var definitionError *state_machine.DefinitionError
+	if !errors.As(err, &definitionError) {
+		return err
+	}
+	var problems []error
+	for _, problem := range definitionError.Problems {
+		var shadowed *state_machine.ShadowedStateError
+		if !errors.As(problem, &shadowed) {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &state_machine.DefinitionError{Problems: problems}
+}
+
+type grammarTokenType int
+
+const (
+	grammarIdentifier grammarTokenType = iota
+	grammarLiteral
+	grammarRegexp
+	grammarSymbol
+)
+
+type grammarToken struct {
+	tokenType grammarTokenType
+	value     string
+	line      int
+	column    int
+}
+
+// lexGrammar splits the grammar definition into tokens
+func lexGrammar(definition string) ([]grammarToken, error) {
+	var tokens []grammarToken
+	runes := []rune(definition)
+	line, column := 1, 1
+
+	advance := func() rune {
+		r := runes[0]
+		runes = runes[1:]
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+		return r
+	}
+
+	for len(runes) > 0 {
+		r := runes[0]
+		startLine, startColumn := line, column
+		switch {
+		case unicode.IsSpace(r):
+			advance()
+		case r == '#':
+			for len(runes) > 0 && runes[0] != '\n' {
+				advance()
+			}
+		case r == '"' || r == '/':
+			// literals and regular expressions: the delimiter (and, in literals, the backslash) can be escaped with a backslash
+			delimiter := advance()
+			value := strings.Builder{}
+			closed := false
+			for len(runes) > 0 && !closed {
+				c := advance()
+				switch {
+				case c == '\\' && len(runes) > 0:
+					// regular expressions keep the escape sequences, except the escaped delimiter
+					escaped := advance()
+					if escaped != delimiter && delimiter == '/' {
+						value.WriteRune(c)
+					}
+					value.WriteRune(escaped)
+				case c == delimiter:
+					closed = true
+				case c == '\n':
+					return nil, &GrammarSyntaxError{Line: startLine, Column: startColumn, Err: fmt.Errorf("unterminated %c", delimiter)}
+				default:
+					value.WriteRune(c)
+				}
+			}
+			if !closed {
+				return nil, &GrammarSyntaxError{Line: startLine, Column: startColumn, Err: fmt.Errorf("unterminated %c", delimiter)}
+			}
+			tokenType := grammarLiteral
+			if delimiter == '/' {
+				tokenType = grammarRegexp
+			}
+			tokens = append(tokens, grammarToken{tokenType: tokenType, value: value.String(), line: startLine, column: startColumn})
+		case r == '-' && len(runes) > 1 && runes[1] == '>':
+			advance()
+			advance()
+			tokens = append(tokens, grammarToken{tokenType: grammarSymbol, value: "->", line: startLine, column: startColumn})
+		case strings.ContainsRune("=|;<>", r):
+			tokens = append(tokens, grammarToken{tokenType: grammarSymbol, value: string(advance()), line: startLine, column: startColumn})
+		case r == '_' || unicode.IsLetter(r):
+			value := strings.Builder{}
+			for len(runes) > 0 && (runes[0] == '_' || unicode.IsLetter(runes[0]) || unicode.IsDigit(runes[0])) {
+				value.WriteRune(advance())
+			}
+			tokens = append(tokens, grammarToken{tokenType: grammarIdentifier, value: value.String(), line: startLine, column: startColumn})
+		default:
+			return nil, &GrammarSyntaxError{Line: startLine, Column: startColumn, Err: fmt.Errorf("unexpected character '%c'", r)}
+		}
+	}
+	return tokens, nil
+}
+
+// grammarLoader - builds the grammar from the tokens of the definition
+type grammarLoader struct {
+	tokens  []grammarToken
+	grammar Grammar
+	// transitions - the index of the transitions of each token into grammar.Transitions
+	transitions map[string]int
+	// last - the last consumed token, used to report errors at the end of the definition
+	last grammarToken
+}
+
+func (l *grammarLoader) eof() bool {
+	return len(l.tokens) == 0
+}
+
+func (l *grammarLoader) errorf(format string, args ...interface{}) error {
+	position := l.last
+	if !l.eof() {
+		position = l.tokens[0]
+	}
+	return &GrammarSyntaxError{Line: position.line, Column: position.column, Err: fmt.Errorf(format, args...)}
+}
+
+func (l *grammarLoader) next() grammarToken {
+	l.last = l.tokens[0]
+	l.tokens = l.tokens[1:]
+	return l.last
+}
+
+// expect consumes the next token, returning an error if it is not of the expected type (and value, if not empty)
+func (l *grammarLoader) expect(tokenType grammarTokenType, value string, description string) (grammarToken, error) {
+	if l.eof() {
+		return grammarToken{}, l.errorf("unexpected end of the definition, expected %s", description)
+	}
+	if l.tokens[0].tokenType != tokenType || (value != "" && l.tokens[0].value != value) {
+		return grammarToken{}, l.errorf("unexpected '%s', expected %s", l.tokens[0].value, description)
+	}
+	return l.next(), nil
+}
+
+func (l *grammarLoader) peekSymbol(symbol string) bool {
+	return !l.eof() && l.tokens[0].tokenType == grammarSymbol && l.tokens[0].value == symbol
+}
+
+// statement parses a token definition or a transition
+func (l *grammarLoader) statement() error {
+	name, err := l.expect(grammarIdentifier, "", "a token name")
+	if err != nil {
+		return err
+	}
+	if l.peekSymbol("->") {
+		l.next()
+		return l.transition(name)
+	}
+	return l.tokenDefinition(name)
+}
+
+// tokenDefinition parses `[<FAMILY>] = "literal" | /regexp/ ;`
+func (l *grammarLoader) tokenDefinition(name grammarToken) error {
+	if name.value == grammarStart || name.value == grammarEnd {
+		return &GrammarSyntaxError{Line: name.line, Column: name.column, Err: fmt.Errorf("'%s' is reserved", name.value)}
+	}
+	for _, token := range l.grammar.Tokens {
+		if token.Name == name.value {
+			return &GrammarSyntaxError{Line: name.line, Column: name.column, Err: fmt.Errorf("token '%s' defined more than once", name.value)}
+		}
+	}
+
+	definition := TokenDefinition{Name: name.value}
+	if l.peekSymbol("<") {
+		l.next()
+		family, err := l.expect(grammarIdentifier, "", "a token family")
+		if err != nil {
+			return err
+		}
+		if _, err := l.expect(grammarSymbol, ">", "'>'"); err != nil {
+			return err
+		}
+		definition.StateData = family.value
+	}
+
+	if _, err := l.expect(grammarSymbol, "=", "'=' or '->'"); err != nil {
+		return err
+	}
+
+	switch {
+	case !l.eof() && l.tokens[0].tokenType == grammarLiteral:
+		literal := l.next().value
+		definition.Acceptor = StringAcceptor(literal)
+		// the literal is the only accepted value: it is used to validate the grammar and by Complete
+		definition.Examples = []string{literal}
+	case !l.eof() && l.tokens[0].tokenType == grammarRegexp:
+		expr := l.next()
+		// RegexpAcceptor refuses everything when the expression is invalid: report it here instead
+		if _, err := regexp.Compile(expr.value); err != nil {
+			return &GrammarSyntaxError{Line: expr.line, Column: expr.column, Err: fmt.Errorf("invalid regular expression for token '%s': %v", name.value, err)}
+		}
+		// RegexpAcceptor only adds the missing anchors: the expression is grouped so that alternations match the whole token
+		definition.Acceptor = RegexpAcceptor(fmt.Sprintf(`^(?:%s)$`, expr.value))
+	default:
+		_, err := l.expect(grammarLiteral, "", "a literal or a regular expression")
+		return err
+	}
+
+	if _, err := l.expect(grammarSymbol, ";", "';'"); err != nil {
+		return err
+	}
+	l.grammar.Tokens = append(l.grammar.Tokens, definition)
+	return nil
+}
+
+// transition parses `NAME | NAME ... ;`
+func (l *grammarLoader) transition(from grammarToken) error {
+	var targets []string
+	for {
+		target, err := l.expect(grammarIdentifier, "", "a token name")
+		if err != nil {
+			return err
+		}
+		targets = append(targets, stateName(target.value))
+		if l.peekSymbol(";") {
+			l.next()
+			break
+		}
+		if _, err := l.expect(grammarSymbol, "|", "'|' or ';'"); err != nil {
+			return err
+		}
+	}
+
+	// the transitions of the same token can be split into more statements
+	name := stateName(from.value)
+	if i, ok := l.transitions[name]; ok {
+		l.grammar.Transitions[i].ValidTransitions = append(l.grammar.Transitions[i].ValidTransitions, targets...)
+		return nil
+	}
+	l.transitions[name] = len(l.grammar.Transitions)
+	l.grammar.Transitions = append(l.grammar.Transitions, TokenTransitions{TokenName: name, ValidTransitions: targets})
+	return nil
+}
+
+// stateName maps the START and END names to the names of the start and end states
+func stateName(name string) string {
+	switch name {
+	case grammarStart:
+		return state_machine.StartState
+	case grammarEnd:
+		return state_machine.EndState
+	}
+	return name
+}
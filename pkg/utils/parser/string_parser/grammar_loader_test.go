@@ -0,0 +1,115 @@
+package string_parser
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+)
+
+var _ = Describe("Grammar loader", func() {
+	// signed decimal numbers, one char per token: -12.50
+	numbersGrammar := `
+		# tokens
+		SIGN  <SYMBOL> = "-" ;
+		DIGIT <DIGIT>  = /[0-9]/ ;
+		POINT <SYMBOL> = "." ;  # the decimal point
+		DECIMAL <DIGIT> = /[0-9]/ ;
+
+		# transitions
+		START   -> SIGN | DIGIT ;
+		SIGN    -> DIGIT ;
+		DIGIT   -> DIGIT | POINT ;
+		DIGIT   -> END ;
+		POINT   -> DECIMAL ;
+		DECIMAL -> DECIMAL | END ;
+	`
+
+	It("Compiles the DSL to a grammar", func() {
+		grammar, err := LoadGrammar(strings.NewReader(numbersGrammar))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(grammar.Tokens).To(HaveLen(4))
+		Expect(grammar.Tokens[0].Name).To(Equal("SIGN"))
+		Expect(grammar.Tokens[0].StateData).To(Equal("SYMBOL"))
+		Expect(grammar.Tokens[0].Examples).To(Equal([]string{"-"}))
+		Expect(grammar.Tokens[1].Examples).To(BeEmpty())
+		Expect(grammar.Transitions).To(Equal([]TokenTransitions{
+			{TokenName: state_machine.StartState, ValidTransitions: []string{"SIGN", "DIGIT"}},
+			{TokenName: "SIGN", ValidTransitions: []string{"DIGIT"}},
+			{TokenName: "DIGIT", ValidTransitions: []string{"DIGIT", "POINT", state_machine.EndState}},
+			{TokenName: "POINT", ValidTransitions: []string{"DECIMAL"}},
+			{TokenName: "DECIMAL", ValidTransitions: []string{"DECIMAL", state_machine.EndState}},
+		}))
+	})
+
+	DescribeTable("Parsing with a loaded grammar", func(value string, expectedErr string) {
+		grammar, err := ParseGrammar(numbersGrammar)
+		Expect(err).ToNot(HaveOccurred())
+		err = NewStringParserBuilder().WithGrammar(grammar).Build().Parse(value)
+		if expectedErr == "" {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("integer", "42", ""),
+		Entry("negative decimal", "-12.50", ""),
+		Entry("two signs", "--1", "[2] error parsing the filter: unexpected token `-`"),
+		Entry("missing decimals", "1.", "EOF encountered while parsing string"),
+	)
+
+	It("Supports escapes", func() {
+		grammar, err := ParseGrammar(`
+			QUOTE = "\"" ; BACKSLASH = "\\" ; SLASH = /\/|\\/ ;
+			START -> QUOTE ; QUOTE -> BACKSLASH ; BACKSLASH -> SLASH ; SLASH -> SLASH | END ;
+		`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(NewStringParserBuilder().WithGrammar(grammar).Build().Parse(`"\/\`)).To(Succeed())
+	})
+
+	DescribeTable("Syntax errors", func(definition string, expectedErr string) {
+		_, err := ParseGrammar(definition)
+		Expect(err).To(MatchError(expectedErr))
+		var syntaxError *GrammarSyntaxError
+		Expect(errors.As(err, &syntaxError)).To(BeTrue())
+	},
+		Entry("unexpected character", "A = 'a' ;", "[1:5] error loading the grammar: unexpected character '''"),
+		Entry("unterminated literal", "A = \"a ;\nSTART -> A ;", "[1:5] error loading the grammar: unterminated \""),
+		Entry("missing semicolon", "A = \"a\"\nSTART -> A ;", "[2:1] error loading the grammar: unexpected 'START', expected ';'"),
+		Entry("missing acceptor", "A <FAMILY> = ;", "[1:14] error loading the grammar: unexpected ';', expected a literal or a regular expression"),
+		Entry("unexpected end", "A -> B |", "[1:8] error loading the grammar: unexpected end of the definition, expected a token name"),
+		Entry("reserved name", "END = \"a\" ;", "[1:1] error loading the grammar: 'END' is reserved"),
+		Entry("duplicated token", "A = \"a\" ;\nA = \"b\" ;", "[2:1] error loading the grammar: token 'A' defined more than once"),
+		Entry("invalid regular expression", "A = \"a\" ;\nB = /[a-z/ ;",
+			"[2:5] error loading the grammar: invalid regular expression for token 'B': error parsing regexp: missing closing ]: `[a-z`"),
+	)
+
+	It("Anchors the regular expressions as a whole", func() {
+		grammar, err := ParseGrammar(`WORD = /ab|cd/ ; START -> WORD ; WORD -> END ;`)
+		Expect(err).ToNot(HaveOccurred())
+		accept := grammar.Tokens[0].Acceptor
+		Expect(accept("ab")).To(BeTrue())
+		Expect(accept("cd")).To(BeTrue())
+		Expect(accept("abxx")).To(BeFalse())
+		Expect(accept("xxcd")).To(BeFalse())
+
+		// RegexpAcceptor used directly keeps adding only the missing anchors
+		Expect(RegexpAcceptor("ab|cd")("abxx")).To(BeTrue())
+	})
+
+	It("Validates the grammar", func() {
+		_, err := ParseGrammar(`A = "a" ; START -> A ; A -> B ;`)
+		var definitionError *state_machine.DefinitionError
+		Expect(errors.As(err, &definitionError)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("unknown state 'B' referenced by state 'A'")))
+	})
+
+	It("Loads the tokens shadowed by a previous token", func() {
+		// X is reached only when backtracking
+		grammar, err := ParseGrammar(`ANY = /.*/ ; X = "x" ; START -> ANY | X ; ANY -> END ; X -> END ;`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(grammar.Validate()).To(MatchError(ContainSubstring("transition from '__$$_START_$$__' to 'X' is shadowed by the transition to 'ANY'")))
+	})
+})
@@ -0,0 +1,29 @@
+package string_parser
+
+import "fmt"
+
+// ParseError - the error returned when a string can't be parsed
+type ParseError struct {
+	// Position - the position (0 based) of the offending token. When Eof is true, this is the length of the parsed string
+	Position int
+	// Token - the offending token. Empty when Eof is true
+	Token string
+	// Eof - true if the end of the string has been reached before the parsing could be completed
+	Eof bool
+	// Expected - the names of the tokens that would have been valid at Position. Empty if the token was valid but has been
+	// refused for other reasons (ie: by a transition interceptor)
+	Expected []string
+	// Err - the underlying error
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Eof {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("[%d] error parsing the filter: %v", e.Position+1, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
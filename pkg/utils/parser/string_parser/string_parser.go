@@ -1,38 +1,82 @@
 package string_parser
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_scanner"
 )
 
+// StringParser - parses strings using the configured grammar. The StringParser is safe for concurrent use as long as the
+// configured interceptor and observers are.
 type StringParser struct {
-	stateMachineStart *state_machine.State[string, string]
-	scanner           string_scanner.Scanner
+	parser *state_machine.TokenParser[string, string]
+
+	// scannerFactory creates a new scanner for each Parse call
+	scannerFactory func() string_scanner.Scanner
+	// scanner is the shared scanner configured with WithScanner. Accesses are serialised by scannerLock.
+	scanner     string_scanner.Scanner
+	scannerLock sync.Mutex
+
+	// resolutionMode - how the tokens accepted by more than one of the next states are resolved
+	resolutionMode state_machine.ResolutionMode
+	// suggestionProvider - used by Complete to suggest the values of the tokens
+	suggestionProvider SuggestionProvider
 }
 
 func (p *StringParser) Parse(sql string) error {
-	p.reset()
+	return p.ParseWithInterceptor(sql, nil)
+}
 
-	state := p.stateMachineStart
+// ParseWithInterceptor - parses the string calling the received interceptor (after the one configured in the builder) for
+// each transition. Since the interceptor is used only for the current call, it can safely keep the state of the parsing.
+// The observers are notified only of the transitions accepted by the interceptor.
+func (p *StringParser) ParseWithInterceptor(sql string, interceptor state_machine.TransitionInterceptor[string, string]) error {
+	tokens := p.scan(sql)
+	values := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		values = append(values, token.Value)
+	}
 
-	scanner := p.scanner
-	scanner.Init(sql)
+	err := p.parser.ParseWithInterceptor(values, interceptor)
+	var tokenParseError *state_machine.TokenParseError[string]
+	if !errors.As(err, &tokenParseError) {
+		return err
+	}
 
-	for scanner.Next() {
-		if next, err := state.Move(scanner.Token().Value); err != nil {
-			return fmt.Errorf("[%d] error parsing the filter: %v", scanner.Token().Position+1, err)
-		} else {
-			state = next
+	// map the index of the token to its position into the string
+	if tokenParseError.Eof {
+		return &ParseError{
+			Position: len(sql),
+			Eof:      true,
+			Expected: tokenParseError.Expected,
+			Err:      fmt.Errorf(`EOF encountered while parsing string`),
 		}
 	}
-
-	if !state.Eof() {
-		return fmt.Errorf(`EOF encountered while parsing string`)
+	return &ParseError{
+		Position: tokens[tokenParseError.Index].Position,
+		Token:    tokenParseError.Token,
+		Expected: tokenParseError.Expected,
+		Err:      tokenParseError.Err,
 	}
-
-	return nil
 }
 
-func (p *StringParser) reset() {
+// scan splits the string into tokens
+func (p *StringParser) scan(sql string) []string_scanner.Token {
+	scanner := p.scanner
+	if p.scannerFactory != nil {
+		scanner = p.scannerFactory()
+	} else {
+		p.scannerLock.Lock()
+		defer p.scannerLock.Unlock()
+	}
+
+	var tokens []string_scanner.Token
+	scanner.Init(sql)
+	for scanner.Next() {
+		tokens = append(tokens, *scanner.Token())
+	}
+	return tokens
 }
@@ -6,14 +6,42 @@ import (
 )
 
 type StringParserBuilder struct {
-	grammar     Grammar
-	scanner     string_scanner.Scanner
-	interceptor state_machine.TransitionInterceptor[string, string]
-	observers   []state_machine.TransitionObserver[string, string]
+	grammar            Grammar
+	scanner            string_scanner.Scanner
+	scannerFactory     func() string_scanner.Scanner
+	interceptor        state_machine.TransitionInterceptor[string, string]
+	observers          []state_machine.TransitionObserver[string, string]
+	resolutionMode     state_machine.ResolutionMode
+	suggestionProvider SuggestionProvider
 }
 
+// WithScanner - configures the scanner to be used. Since scanners are stateful, the built parser serialises the accesses
+// to the scanner: use WithScannerFactory to get parsers that can scan concurrently.
 func (spb *StringParserBuilder) WithScanner(scanner string_scanner.Scanner) *StringParserBuilder {
 	spb.scanner = scanner
+	spb.scannerFactory = nil
+	return spb
+}
+
+// WithScannerFactory - configures the function used to create a new scanner for each parsed string
+func (spb *StringParserBuilder) WithScannerFactory(scannerFactory func() string_scanner.Scanner) *StringParserBuilder {
+	spb.scannerFactory = scannerFactory
+	spb.scanner = nil
+	return spb
+}
+
+// WithResolutionMode - configures how the tokens accepted by more than one of the valid next tokens are resolved. Defaults to
+// state_machine.FirstMatch: with state_machine.Backtracking or state_machine.StrictBacktracking, a token accepted by the
+// wrong next token doesn't make the parsing fail anymore, so the transitions of the grammar don't need to be carefully ordered.
+func (spb *StringParserBuilder) WithResolutionMode(resolutionMode state_machine.ResolutionMode) *StringParserBuilder {
+	spb.resolutionMode = resolutionMode
+	return spb
+}
+
+// WithSuggestionProvider - configures the provider of the values suggested by StringParser.Complete, besides the examples
+// of the token definitions
+func (spb *StringParserBuilder) WithSuggestionProvider(suggestionProvider SuggestionProvider) *StringParserBuilder {
+	spb.suggestionProvider = suggestionProvider
 	return spb
 }
 
@@ -34,20 +62,23 @@ func (spb *StringParserBuilder) WithTransitionObserver(observer state_machine.Tr
 
 func (spb *StringParserBuilder) Build() *StringParser {
 	builder := state_machine.NewStateMachineBuilder[string, string]().
-		WithStateMachineDefinition(spb.grammar.toStateMachineDefinition()).
+		WithStateMachineDefinition(spb.grammar.StateMachineDefinition()).
 		WithTransitionInterceptor(spb.interceptor)
 
 	for _, observer := range spb.observers {
 		builder = builder.WithTransitionObserver(observer)
 	}
 	return &StringParser{
-		stateMachineStart: builder.Build(),
-		scanner:           spb.scanner,
+		parser:             state_machine.NewTokenParser(builder.Build(), spb.resolutionMode),
+		scanner:            spb.scanner,
+		scannerFactory:     spb.scannerFactory,
+		resolutionMode:     spb.resolutionMode,
+		suggestionProvider: spb.suggestionProvider,
 	}
 }
 
 func NewStringParserBuilder() *StringParserBuilder {
 	return &StringParserBuilder{
-		scanner: string_scanner.NewSimpleScanner(), // defaults to char by char scanner
+		scannerFactory: string_scanner.NewSimpleScanner, // defaults to char by char scanner
 	}
 }
@@ -0,0 +1,13 @@
+package string_parser_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStringParser(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "StringParser Suite")
+}
@@ -0,0 +1,64 @@
+package string_parser
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/state_machine"
+	"github.com/openshift-online/ocm-common/pkg/utils/parser/string_scanner"
+)
+
+var _ = Describe("Typed tokens", func() {
+	const (
+		WORD = iota
+		OPERATOR
+		STRING
+	)
+	lexer := string_scanner.NewLexerBuilder().
+		WithSkipRule(`\s+`).
+		WithQuoteRule(STRING, '\'', '\\').
+		WithLiteralRule(OPERATOR, "=", "<>").
+		WithRegexpRule(WORD, `[a-zA-Z_][a-zA-Z0-9_]*`).
+		Build()
+
+	// `column = 'value' [and column = 'value' ...]`: the acceptors check only the type of the tokens, except for the keywords
+	definition := state_machine.StateMachineDefinition[string, string_scanner.Token]{
+		States: []state_machine.StateDefinition[string, string_scanner.Token]{
+			{Name: "AND", Acceptor: TokenValueAcceptor(WORD, "and")},
+			{Name: "COLUMN", Acceptor: TokenTypeAcceptor(WORD)},
+			{Name: "OP", Acceptor: TokenTypeAcceptor(OPERATOR)},
+			{Name: "VALUE", Acceptor: TokenTypeAcceptor(STRING)},
+		},
+		Transitions: []state_machine.TransitionDefinition{
+			{StateName: state_machine.StartState, ValidTransitions: []string{"COLUMN"}},
+			{StateName: "COLUMN", ValidTransitions: []string{"OP"}},
+			{StateName: "OP", ValidTransitions: []string{"VALUE"}},
+			{StateName: "VALUE", ValidTransitions: []string{"AND", state_machine.EndState}},
+			{StateName: "AND", ValidTransitions: []string{"COLUMN"}},
+		},
+	}
+	start := state_machine.NewStateMachineBuilder[string, string_scanner.Token]().WithStateMachineDefinition(&definition).Build()
+	parser := state_machine.NewTokenParser(start, state_machine.FirstMatch)
+
+	It("Parses the scanned tokens", func() {
+		var columns []string
+		err := parser.ParseWithInterceptor(lexer.Tokenize("name = 'mickey' AND owner <> 'it\\'s'"),
+			func(from, to *state_machine.State[string, string_scanner.Token], token string_scanner.Token) error {
+				if to.Name() == "COLUMN" {
+					columns = append(columns, token.Value)
+				}
+				return nil
+			})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(columns).To(Equal([]string{"name", "owner"}))
+	})
+
+	It("Reports the offending token", func() {
+		err := parser.Parse(lexer.Tokenize("name = 'mickey' or owner = 'minnie'"))
+		var parseError *state_machine.TokenParseError[string_scanner.Token]
+		Expect(errors.As(err, &parseError)).To(BeTrue())
+		Expect(parseError.Token).To(Equal(string_scanner.Token{TokenType: WORD, Value: "or", Position: 16}))
+		Expect(parseError.Expected).To(Equal([]string{"AND"}))
+	})
+})
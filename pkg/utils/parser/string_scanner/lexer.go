@@ -0,0 +1,201 @@
+package string_scanner
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+// UNMATCHED - the TokenType of the tokens returned by the Lexer for the characters not matched by any rule. Each unmatched
+// character is returned as a separate token, so that the parser can report it.
+const UNMATCHED = -1
+
+type lexerRule struct {
+	tokenType int
+	skip      bool
+	// literals - sorted by length, so that the longest literal wins (ie: `->>` over `->`)
+	literals []string
+	regexp   *regexp.Regexp
+	// quote - if not 0, this is a quote rule
+	quote  rune
+	escape rune
+}
+
+// match returns the length of the text matched by the rule at the beginning of s (0 if the rule doesn't match)
+func (r *lexerRule) match(s string) int {
+	switch {
+	case r.quote != 0:
+		return r.matchQuoted(s)
+	case r.regexp != nil:
+		if loc := r.regexp.FindStringIndex(s); loc != nil {
+			return loc[1]
+		}
+	default:
+		for _, literal := range r.literals {
+			if len(s) >= len(literal) && s[:len(literal)] == literal {
+				return len(literal)
+			}
+		}
+	}
+	return 0
+}
+
+// matchQuoted matches from the opening quote to the first not escaped closing quote, or to the end of the string if the
+// quote is never closed
+func (r *lexerRule) matchQuoted(s string) int {
+	c, size := utf8.DecodeRuneInString(s)
+	if c != r.quote {
+		return 0
+	}
+	i := size
+	for i < len(s) {
+		c, size = utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case r.escape != 0 && c == r.escape:
+			// skip the escaped quote
+			if next, nextSize := utf8.DecodeRuneInString(s[i:]); next == r.quote {
+				i += nextSize
+			}
+		case c == r.quote:
+			return i
+		}
+	}
+	return i
+}
+
+// LexerBuilder - configures the rules of a Lexer. The rules are evaluated in the order they are added: at each position of
+// the string, the first matching rule produces the token.
+type LexerBuilder struct {
+	rules []lexerRule
+}
+
+// WithLiteralRule - adds a rule producing a token of the given type for each of the literals. When more literals match,
+// the longest wins.
+func (lb *LexerBuilder) WithLiteralRule(tokenType int, literals ...string) *LexerBuilder {
+	sorted := append([]string{}, literals...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	lb.rules = append(lb.rules, lexerRule{tokenType: tokenType, literals: sorted})
+	return lb
+}
+
+// WithRegexpRule - adds a rule producing a token of the given type for the text matching the regular expression. The
+// expression is matched at the current position only. Panics if the expression is not valid.
+func (lb *LexerBuilder) WithRegexpRule(tokenType int, expr string) *LexerBuilder {
+	lb.rules = append(lb.rules, lexerRule{tokenType: tokenType, regexp: compileRule(expr)})
+	return lb
+}
+
+// WithSkipRule - adds a rule discarding the text matching the regular expression (ie: whitespaces and comments)
+func (lb *LexerBuilder) WithSkipRule(expr string) *LexerBuilder {
+	lb.rules = append(lb.rules, lexerRule{skip: true, regexp: compileRule(expr)})
+	return lb
+}
+
+// WithQuoteRule - adds a rule producing a token of the given type for the text surrounded by the quote character. Inside
+// the quotes, a quote preceded by the escape character (if not 0) doesn't close the token: the escape character is
+// a plain character anywhere else. The token value includes the quotes and the escape characters. An unterminated quote
+// produces a token up to the end of the string.
+func (lb *LexerBuilder) WithQuoteRule(tokenType int, quote rune, escape rune) *LexerBuilder {
+	lb.rules = append(lb.rules, lexerRule{tokenType: tokenType, quote: quote, escape: escape})
+	return lb
+}
+
+func (lb *LexerBuilder) Build() *Lexer {
+	return &Lexer{rules: append([]lexerRule{}, lb.rules...)}
+}
+
+func NewLexerBuilder() *LexerBuilder {
+	return &LexerBuilder{}
+}
+
+// compileRule anchors the expression to the current position
+func compileRule(expr string) *regexp.Regexp {
+	re, err := regexp.Compile(`\A(?:` + expr + `)`)
+	if err != nil {
+		panic(fmt.Errorf("invalid lexer rule `%s`: %w", expr, err))
+	}
+	return re
+}
+
+// Lexer - splits strings into tokens using an ordered list of rules. A Lexer is immutable and can be shared: use NewScanner
+// to get a Scanner.
+type Lexer struct {
+	rules []lexerRule
+}
+
+// Tokenize - splits the string into tokens
+func (l *Lexer) Tokenize(s string) []Token {
+	return l.TokenizeN(s, -1)
+}
+
+// TokenizeN - splits the string into tokens, stopping after n tokens. The rest of the string is not scanned, so that
+// limiting the number of tokens also limits the work done on long strings. n < 0 means no limit.
+func (l *Lexer) TokenizeN(s string, n int) []Token {
+	var tokens []Token
+	pos := 0
+	for pos < len(s) && (n < 0 || len(tokens) < n) {
+		matched := false
+		for i := range l.rules {
+			rule := &l.rules[i]
+			// rules matching the empty string would never move forward
+			if length := rule.match(s[pos:]); length > 0 {
+				if !rule.skip {
+					tokens = append(tokens, Token{TokenType: rule.tokenType, Value: s[pos : pos+length], Position: pos})
+				}
+				pos += length
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			_, size := utf8.DecodeRuneInString(s[pos:])
+			tokens = append(tokens, Token{TokenType: UNMATCHED, Value: s[pos : pos+size], Position: pos})
+			pos += size
+		}
+	}
+	return tokens
+}
+
+// NewScanner - returns a new Scanner splitting the strings with the rules of the lexer
+func (l *Lexer) NewScanner() Scanner {
+	return &lexerScanner{lexer: l, pos: -1}
+}
+
+var _ Scanner = &lexerScanner{}
+
+type lexerScanner struct {
+	lexer  *Lexer
+	tokens []Token
+	pos    int
+}
+
+func (s *lexerScanner) Init(value string) {
+	s.tokens = s.lexer.Tokenize(value)
+	s.pos = -1
+}
+
+func (s *lexerScanner) Next() bool {
+	if s.pos < len(s.tokens)-1 {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+func (s *lexerScanner) Peek() (bool, *Token) {
+	if s.pos < len(s.tokens)-1 {
+		ret := s.tokens[s.pos+1]
+		return true, &ret
+	}
+	return false, nil
+}
+
+func (s *lexerScanner) Token() *Token {
+	if s.pos < 0 || s.pos >= len(s.tokens) {
+		panic(fmt.Errorf("invalid scanner position %d", s.pos))
+	}
+	ret := s.tokens[s.pos]
+	return &ret
+}
@@ -0,0 +1,102 @@
+package string_scanner
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lexer", func() {
+	const (
+		WORD = iota
+		NUMBER
+		OPERATOR
+		STRING
+	)
+
+	lexer := NewLexerBuilder().
+		WithSkipRule(`\s+`).
+		WithSkipRule(`#[^\n]*`).
+		WithQuoteRule(STRING, '"', '\\').
+		WithLiteralRule(OPERATOR, "=", "==", "!=").
+		WithRegexpRule(NUMBER, `[0-9]+`).
+		WithRegexpRule(WORD, `[a-zA-Z_][a-zA-Z0-9_]*`).
+		Build()
+
+	DescribeTable("Tokenize", func(value string, expectedTokens []Token) {
+		Expect(lexer.Tokenize(value)).To(Equal(expectedTokens))
+	},
+		Entry("Empty string", "", nil),
+		Entry("Only skipped text", "  # a comment", nil),
+		Entry("Rules", "a1 == 42", []Token{
+			{TokenType: WORD, Value: "a1", Position: 0},
+			{TokenType: OPERATOR, Value: "==", Position: 3},
+			{TokenType: NUMBER, Value: "42", Position: 6},
+		}),
+		Entry("No separators needed", "a!=1", []Token{
+			{TokenType: WORD, Value: "a", Position: 0},
+			{TokenType: OPERATOR, Value: "!=", Position: 1},
+			{TokenType: NUMBER, Value: "1", Position: 3},
+		}),
+		Entry("Rules order", "42abc", []Token{
+			{TokenType: NUMBER, Value: "42", Position: 0},
+			{TokenType: WORD, Value: "abc", Position: 2},
+		}),
+		Entry("Comments", "a # comment\n= b", []Token{
+			{TokenType: WORD, Value: "a", Position: 0},
+			{TokenType: OPERATOR, Value: "=", Position: 12},
+			{TokenType: WORD, Value: "b", Position: 14},
+		}),
+		Entry("Quotes", `a = "x # \"y\" \z"`, []Token{
+			{TokenType: WORD, Value: "a", Position: 0},
+			{TokenType: OPERATOR, Value: "=", Position: 2},
+			{TokenType: STRING, Value: `"x # \"y\" \z"`, Position: 4},
+		}),
+		Entry("Unterminated quote", `a = "x`, []Token{
+			{TokenType: WORD, Value: "a", Position: 0},
+			{TokenType: OPERATOR, Value: "=", Position: 2},
+			{TokenType: STRING, Value: `"x`, Position: 4},
+		}),
+		Entry("Unmatched characters", "a ?é", []Token{
+			{TokenType: WORD, Value: "a", Position: 0},
+			{TokenType: UNMATCHED, Value: "?", Position: 2},
+			{TokenType: UNMATCHED, Value: "é", Position: 3},
+		}),
+	)
+
+	It("Ignores the rules matching the empty string", func() {
+		lexer := NewLexerBuilder().WithRegexpRule(WORD, `[a-z]*`).Build()
+		Expect(lexer.Tokenize("ab1")).To(Equal([]Token{
+			{TokenType: WORD, Value: "ab", Position: 0},
+			{TokenType: UNMATCHED, Value: "1", Position: 2},
+		}))
+	})
+
+	It("Stops after the maximum number of tokens", func() {
+		Expect(lexer.TokenizeN("a = 1 b", 2)).To(Equal([]Token{
+			{TokenType: WORD, Value: "a", Position: 0},
+			{TokenType: OPERATOR, Value: "=", Position: 2},
+		}))
+		Expect(lexer.TokenizeN("a = 1", 0)).To(BeEmpty())
+		Expect(lexer.TokenizeN("a = 1", -1)).To(Equal(lexer.Tokenize("a = 1")))
+	})
+
+	It("Panics on invalid regular expressions", func() {
+		Expect(func() { NewLexerBuilder().WithRegexpRule(WORD, `[a-z`) }).To(Panic())
+	})
+
+	It("Scans the tokens", func() {
+		scanner := lexer.NewScanner()
+		scanner.Init("a = 1")
+		Expect(func() { scanner.Token() }).To(Panic())
+
+		var tokens []string
+		for scanner.Next() {
+			tokens = append(tokens, scanner.Token().Value)
+			if ok, next := scanner.Peek(); ok {
+				Expect(next.Position).To(BeNumerically(">", scanner.Token().Position))
+			}
+		}
+		Expect(tokens).To(Equal([]string{"a", "=", "1"}))
+		Expect(scanner.Peek()).To(BeFalse())
+	})
+})